@@ -0,0 +1,66 @@
+package gonull
+
+// NullableBitset is a nullable integer bitmask, typically used for permission or flag columns stored as integers.
+// It embeds Nullable[int64], so it scans from and values to an int64 and marshals to JSON like Nullable[int64],
+// while providing methods to test and modify individual bits without manual bit twiddling at call sites.
+type NullableBitset struct {
+	Nullable[int64]
+}
+
+// NewNullableBitset creates a new valid NullableBitset with the given flags set.
+// Each flag is the zero-based position of a bit to set.
+func NewNullableBitset(bits ...uint) NullableBitset {
+	b := NullableBitset{Nullable: NewNullable[int64](0)}
+	for _, bit := range bits {
+		b.SetBit(bit)
+	}
+	return b
+}
+
+// Has reports whether the given bit is set. It always returns false when the bitset is not valid.
+func (b NullableBitset) Has(bit uint) bool {
+	if !b.Valid || bit >= 64 {
+		return false
+	}
+	return b.Val&(1<<bit) != 0
+}
+
+// SetBit sets the given bit, marking the bitset as valid and present.
+// If the bitset was invalid, it starts from an empty mask. Bits outside the 0-63 range are ignored.
+func (b *NullableBitset) SetBit(bit uint) {
+	if !b.Valid {
+		b.Val = 0
+	}
+	if bit < 64 {
+		b.Val |= 1 << bit
+	}
+	b.Valid = true
+	b.Present = true
+}
+
+// ClearBit clears the given bit. It has no effect when the bitset is not valid.
+func (b *NullableBitset) ClearBit(bit uint) {
+	if !b.Valid || bit >= 64 {
+		return
+	}
+	b.Val &^= 1 << bit
+}
+
+// Flags expands the bitmask into a slice of n booleans, where the element at index i reports whether bit i is set.
+// n is clamped to the range 0 to 64. It returns nil when the bitset is not valid.
+func (b NullableBitset) Flags(n int) []bool {
+	if !b.Valid {
+		return nil
+	}
+	if n > 64 {
+		n = 64
+	} else if n < 0 {
+		n = 0
+	}
+
+	flags := make([]bool, n)
+	for i := range flags {
+		flags[i] = b.Has(uint(i))
+	}
+	return flags
+}
@@ -0,0 +1,75 @@
+package gonull
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNullableBitsetRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		value int64
+		flags []bool
+	}{
+		{name: "read and execute", value: 5, flags: []bool{true, false, true, false}},
+		{name: "all low bits", value: 0b1111, flags: []bool{true, true, true, true}},
+		{name: "empty mask", value: 0, flags: []bool{false, false, false, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b NullableBitset
+			err := b.Scan(tt.value)
+			assert.NoError(t, err)
+			assert.True(t, b.Valid)
+			assert.Equal(t, tt.flags, b.Flags(4))
+
+			value, err := b.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.value, value)
+		})
+	}
+}
+
+func TestNullableBitsetNull(t *testing.T) {
+	var b NullableBitset
+	err := b.Scan(nil)
+	assert.NoError(t, err)
+	assert.False(t, b.Valid)
+	assert.True(t, b.Present)
+	assert.False(t, b.Has(0))
+	assert.Nil(t, b.Flags(4))
+
+	value, err := b.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestNullableBitsetSetAndClearBit(t *testing.T) {
+	b := NewNullableBitset(1, 3)
+	assert.True(t, b.Has(1))
+	assert.True(t, b.Has(3))
+	assert.Equal(t, int64(0b1010), b.Val)
+
+	b.ClearBit(1)
+	assert.False(t, b.Has(1))
+	assert.Equal(t, int64(0b1000), b.Val)
+
+	var invalid NullableBitset
+	invalid.SetBit(0)
+	assert.True(t, invalid.Valid)
+	assert.True(t, invalid.Present)
+	assert.Equal(t, int64(1), invalid.Val)
+}
+
+func TestNullableBitsetFlagsBounds(t *testing.T) {
+	b := NewNullableBitset(0, 63)
+	assert.Equal(t, []bool{}, b.Flags(-1))
+	assert.Equal(t, []bool{}, b.Flags(0))
+
+	flags := b.Flags(100)
+	assert.Len(t, flags, 64)
+	assert.True(t, flags[0])
+	assert.True(t, flags[63])
+}
@@ -0,0 +1,469 @@
+package gonull
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf16"
+)
+
+// settings holds the package-level options that change how values are scanned and valued.
+// It is replaced as a whole on every update, so readers can use a loaded snapshot without locking.
+type settings struct {
+	integerTime       *IntegerTimeFormat
+	boolText          *BoolText
+	timeLayout        string
+	timeLayouts       []string
+	maxLength         int
+	lengthMode        StringLengthMode
+	byteDecoder       ByteDecoder
+	converter         driver.ValueConverter
+	scanLocation      *time.Location
+	patchNullMode     PatchNullMode
+	maxReaderSize     int64
+	boolString        BoolText
+	ambiguousOrder    AmbiguousOrder
+	recordScannedType bool
+	emptyTimeAsNull   bool
+	xmlNullMode       XMLNullMode
+	tomlNullMode      TOMLNullMode
+	byteaDecoding     bool
+	onScanError       func(target reflect.Type, value any) error
+}
+
+// defaultTimeLayouts are tried in order when scanning textual timestamps into time.Time.
+var defaultTimeLayouts = []string{time.RFC3339, time.DateTime, time.DateOnly}
+
+var (
+	currentSettings atomic.Pointer[settings]
+	settingsMu      sync.Mutex
+)
+
+func init() {
+	currentSettings.Store(&settings{timeLayout: time.RFC3339, timeLayouts: defaultTimeLayouts, boolString: BoolTextWords})
+}
+
+// loadSettings returns the current settings snapshot. It must not be modified.
+func loadSettings() *settings {
+	return currentSettings.Load()
+}
+
+// updateSettings applies update to a copy of the current settings and publishes the result.
+func updateSettings(update func(s *settings)) {
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+
+	next := *currentSettings.Load()
+	update(&next)
+	currentSettings.Store(&next)
+}
+
+// IntegerTimeFormat describes how an integer driver value maps to a time.Time,
+// namely as the number of Units elapsed since Epoch.
+type IntegerTimeFormat struct {
+	Epoch time.Time
+	Unit  time.Duration
+}
+
+var (
+	// UnixDays interprets integers as days since the Unix epoch, as some SQLite schemas store dates.
+	UnixDays = IntegerTimeFormat{Epoch: time.Unix(0, 0).UTC(), Unit: 24 * time.Hour}
+
+	// UnixSeconds interprets integers as Unix timestamps in seconds.
+	UnixSeconds = IntegerTimeFormat{Epoch: time.Unix(0, 0).UTC(), Unit: time.Second}
+
+	// UnixMillis interprets integers as Unix timestamps in milliseconds.
+	UnixMillis = IntegerTimeFormat{Epoch: time.Unix(0, 0).UTC(), Unit: time.Millisecond}
+
+	// JulianDays interprets integers as Julian day numbers, which start at noon UTC on November 24, 4714 BC
+	// in the proleptic Gregorian calendar.
+	JulianDays = IntegerTimeFormat{Epoch: time.Date(-4713, time.November, 24, 12, 0, 0, 0, time.UTC), Unit: 24 * time.Hour}
+)
+
+// SetIntegerTimeFormat enables scanning integer driver values into Nullable[time.Time] using the given format.
+// Passing nil restores the default behavior, where integers can't be scanned into time.Time.
+func SetIntegerTimeFormat(format *IntegerTimeFormat) {
+	updateSettings(func(s *settings) {
+		if format == nil || format.Unit <= 0 {
+			s.integerTime = nil
+			return
+		}
+		f := *format
+		s.integerTime = &f
+	})
+}
+
+// timeFromInteger converts value to a time.Time using f. The whole seconds and the sub-second remainder
+// of the unit are accumulated separately, so large day counts don't overflow time.Duration.
+func (f IntegerTimeFormat) timeFromInteger(value int64) time.Time {
+	seconds := value * int64(f.Unit/time.Second)
+	nanos := value * int64(f.Unit%time.Second)
+	return time.Unix(f.Epoch.Unix()+seconds, int64(f.Epoch.Nanosecond())+nanos).In(f.Epoch.Location())
+}
+
+// BoolText is a textual representation of booleans used by drivers or databases that expect text on write.
+type BoolText struct {
+	True  string
+	False string
+}
+
+var (
+	// BoolTextTF writes booleans as "t" and "f", the PostgreSQL text output format.
+	BoolTextTF = BoolText{True: "t", False: "f"}
+
+	// BoolTextDigits writes booleans as "1" and "0".
+	BoolTextDigits = BoolText{True: "1", False: "0"}
+
+	// BoolTextWords writes booleans as "true" and "false".
+	BoolTextWords = BoolText{True: "true", False: "false"}
+)
+
+// SetBoolValueFormat makes Value return valid booleans as text in the given format instead of a native bool.
+// Passing nil restores the default behavior.
+func SetBoolValueFormat(format *BoolText) {
+	updateSettings(func(s *settings) {
+		if format == nil {
+			s.boolText = nil
+			return
+		}
+		f := *format
+		s.boolText = &f
+	})
+}
+
+// SetBoolStringFormat sets the text used when a bool driver value is scanned into Nullable[string],
+// which happens when a boolean column maps to a string field. Passing nil restores the default, BoolTextWords.
+func SetBoolStringFormat(format *BoolText) {
+	updateSettings(func(s *settings) {
+		if format == nil {
+			s.boolString = BoolTextWords
+			return
+		}
+		s.boolString = *format
+	})
+}
+
+// text returns the textual form of b.
+func (f BoolText) text(b bool) string {
+	if b {
+		return f.True
+	}
+	return f.False
+}
+
+// SetTimeStringLayout sets the layout used to format time.Time driver values scanned into string Nullables.
+// An empty layout restores the default, time.RFC3339.
+func SetTimeStringLayout(layout string) {
+	updateSettings(func(s *settings) {
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		s.timeLayout = layout
+	})
+}
+
+// RegisterTimeLayouts sets the ordered list of layouts tried when a string or []byte is scanned into Nullable[time.Time].
+// The first layout that parses the value wins. Calling it without layouts restores the default list,
+// which contains time.RFC3339, the MySQL datetime layout time.DateTime and time.DateOnly.
+// It is safe for concurrent use.
+func RegisterTimeLayouts(layouts ...string) {
+	updateSettings(func(s *settings) {
+		if len(layouts) == 0 {
+			s.timeLayouts = defaultTimeLayouts
+			return
+		}
+		s.timeLayouts = append([]string{}, layouts...)
+	})
+}
+
+// TimeLayouts returns a copy of the layouts currently tried when scanning textual timestamps,
+// so a custom layout can be added with RegisterTimeLayouts(append(TimeLayouts(), layout)...).
+func TimeLayouts() []string {
+	return append([]string{}, loadSettings().timeLayouts...)
+}
+
+// SetEmptyTimeAsNull makes Scan treat an empty string or []byte scanned into Nullable[time.Time] as NULL,
+// for drivers that return "" for NULL timestamp columns. It is disabled by default, where empty text is an error,
+// so bad data isn't masked.
+func SetEmptyTimeAsNull(enabled bool) {
+	updateSettings(func(s *settings) {
+		s.emptyTimeAsNull = enabled
+	})
+}
+
+// StringLengthMode defines what Scan does with strings longer than the limit set by SetMaxStringLength.
+type StringLengthMode int
+
+const (
+	// StringLengthError makes Scan return ErrStringTooLong for oversized strings.
+	StringLengthError StringLengthMode = iota
+	// StringLengthTruncate makes Scan silently cut oversized strings down to the limit.
+	StringLengthTruncate
+)
+
+// ErrStringTooLong is returned by Scan when a string exceeds the limit set by SetMaxStringLength.
+var ErrStringTooLong = errors.New("string exceeds maximum length")
+
+// SetMaxStringLength limits the length, in characters, of strings scanned into string Nullables,
+// which helps catch schema drift for VARCHAR(n) columns. Oversized strings are handled according to mode.
+// A max of 0 or less disables the check, which is the default.
+func SetMaxStringLength(max int, mode StringLengthMode) {
+	updateSettings(func(s *settings) {
+		s.maxLength = max
+		s.lengthMode = mode
+	})
+}
+
+// SetByteaDecoding makes Scan decode the PostgreSQL bytea text formats, hex (`\x0102`) and escape (`\001\002`),
+// when scanning into Nullable[[]byte], for drivers that return bytea columns as text.
+// It is disabled by default, where []byte driver values are taken as is, since binary data
+// may happen to look like either format.
+func SetByteaDecoding(enabled bool) {
+	updateSettings(func(s *settings) {
+		s.byteaDecoding = enabled
+	})
+}
+
+// ByteDecoder decodes a []byte driver value stored in a database charset into a UTF-8 string.
+type ByteDecoder func(b []byte) (string, error)
+
+// ErrOddUTF16Length is returned by DecodeUTF16LE for inputs that aren't made of whole 16-bit code units.
+var ErrOddUTF16Length = errors.New("UTF-16 input has an odd number of bytes")
+
+// DecodeUTF16LE decodes little-endian UTF-16, which SQL Server drivers may return for NVARCHAR columns.
+// A leading byte order mark is dropped and unpaired surrogates are replaced with U+FFFD.
+func DecodeUTF16LE(b []byte) (string, error) {
+	if len(b)%2 != 0 {
+		return "", ErrOddUTF16Length
+	}
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i < len(b); i += 2 {
+		units = append(units, uint16(b[i])|uint16(b[i+1])<<8)
+	}
+	if len(units) > 0 && units[0] == 0xFEFF {
+		units = units[1:]
+	}
+	return string(utf16.Decode(units)), nil
+}
+
+// DecodeLatin1 decodes ISO-8859-1, where every byte is the code point of the same value.
+func DecodeLatin1(b []byte) (string, error) {
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+	return string(runes), nil
+}
+
+// SetStringByteDecoder makes Scan decode []byte driver values with decode when the target is Nullable[string],
+// e.g. DecodeUTF16LE or DecodeLatin1. Passing nil restores the default behavior, where []byte is converted to string
+// as is, as MySQL and SQL Server drivers return text columns.
+func SetStringByteDecoder(decode ByteDecoder) {
+	updateSettings(func(s *settings) {
+		s.byteDecoder = decode
+	})
+}
+
+// SetValueConverter makes Value pass valid values through converter before they are turned into driver values,
+// so custom types convert the same way the driver itself would convert them, e.g. with a driver's own
+// driver.ValueConverter or driver.DefaultParameterConverter. The converted value then goes through
+// the usual Value conversion. Passing nil restores the default behavior.
+func SetValueConverter(converter driver.ValueConverter) {
+	updateSettings(func(s *settings) {
+		s.converter = converter
+	})
+}
+
+// SetOnScanError sets a hook that Scan calls whenever a value can't be converted to T, instead of returning
+// ErrUnsupportedConversion. It receives the target type and the driver value, and its result is returned by Scan,
+// so returning nil swallows the error and leaves the Nullable present but invalid, which suits lenient ingestion.
+// Passing nil restores the default behavior. It is safe for concurrent use.
+func SetOnScanError(hook func(target reflect.Type, value any) error) {
+	updateSettings(func(s *settings) {
+		s.onScanError = hook
+	})
+}
+
+// SetScanTimeLocation makes Scan convert times scanned into Nullable[time.Time] to loc, so downstream code sees
+// consistent zones regardless of whether the driver returns UTC or local times. The instant is not changed.
+// Passing nil restores the default behavior, where times are kept in the location returned by the driver.
+func SetScanTimeLocation(loc *time.Location) {
+	updateSettings(func(s *settings) {
+		s.scanLocation = loc
+	})
+}
+
+// ErrReaderTooLarge is returned by Scan when an io.Reader driver value is longer than the limit set by SetMaxReaderSize.
+var ErrReaderTooLarge = errors.New("reader exceeds maximum size")
+
+// SetMaxReaderSize limits the number of bytes Scan reads from driver values implementing io.Reader,
+// which some drivers return for large objects, to prevent running out of memory on unexpectedly large values.
+// A max of 0 or less disables the check, which is the default.
+func SetMaxReaderSize(max int64) {
+	updateSettings(func(s *settings) {
+		s.maxReaderSize = max
+	})
+}
+
+// AmbiguousOrder defines how Scan resolves a []byte driver value when T is an interface, e.g. Nullable[any],
+// where the bytes could stand for a number, a string or binary data.
+type AmbiguousOrder int
+
+const (
+	// AmbiguousRaw keeps the []byte as is. It is the default.
+	AmbiguousRaw AmbiguousOrder = iota
+	// AmbiguousNumericFirst parses the bytes as an int64, then as a float64, and falls back to a string.
+	AmbiguousNumericFirst
+	// AmbiguousStringFirst converts the bytes to a string.
+	AmbiguousStringFirst
+)
+
+// SetAmbiguousOrder sets the priority of conversions attempted when a []byte is scanned into an interface target.
+// Candidates that don't implement the target interface are skipped, and the raw []byte is used if none does.
+func SetAmbiguousOrder(order AmbiguousOrder) {
+	updateSettings(func(s *settings) {
+		s.ambiguousOrder = order
+	})
+}
+
+// SetRecordScannedType makes Scan record the type of the incoming driver value, which is then returned by ScannedType.
+// It is meant for debugging conversion issues and disabled by default.
+// Recorded types are kept in the Nullable, so they also affect comparisons of Nullables with ==.
+func SetRecordScannedType(enabled bool) {
+	updateSettings(func(s *settings) {
+		s.recordScannedType = enabled
+	})
+}
+
+// XMLNullMode defines how MarshalXML writes a present Nullable that is not valid, and how UnmarshalXML reads empty elements.
+type XMLNullMode int
+
+const (
+	// XMLNullNil writes null values as elements with xsi:nil="true". Empty elements are decoded into T.
+	// It is the default.
+	XMLNullNil XMLNullMode = iota
+	// XMLNullEmpty writes null values as empty elements and decodes empty elements as null values.
+	// Only elements without any content are empty, so whitespace is decoded into T.
+	// Elements with xsi:nil="true" are still decoded as null values.
+	XMLNullEmpty
+)
+
+// SetXMLNullMode sets how null values are represented in XML.
+func SetXMLNullMode(mode XMLNullMode) {
+	updateSettings(func(s *settings) {
+		s.xmlNullMode = mode
+	})
+}
+
+// TOMLNullMode defines how present Nullables that are not valid are encoded to TOML, which has no null.
+type TOMLNullMode int
+
+const (
+	// TOMLNullError makes encoding a null value fail with ErrTOMLNull. It is the default.
+	TOMLNullError TOMLNullMode = iota
+	// TOMLNullAbsent makes EncodeTOML leave null values out of the document as if they were absent.
+	TOMLNullAbsent
+)
+
+// SetTOMLNullMode sets how null values are encoded to TOML. It only has an effect with the "toml" build tag.
+func SetTOMLNullMode(mode TOMLNullMode) {
+	updateSettings(func(s *settings) {
+		s.tomlNullMode = mode
+	})
+}
+
+// PatchNullMode defines which JSON Patch operation PatchOp emits for a present null.
+type PatchNullMode int
+
+const (
+	// PatchNullRemove makes PatchOp emit a remove operation for present nulls.
+	PatchNullRemove PatchNullMode = iota
+	// PatchNullReplace makes PatchOp emit a replace operation with a null value for present nulls.
+	PatchNullReplace
+)
+
+// SetPatchNullMode sets the operation PatchOp emits for present nulls. The default is PatchNullRemove.
+func SetPatchNullMode(mode PatchNullMode) {
+	updateSettings(func(s *settings) {
+		s.patchNullMode = mode
+	})
+}
+
+// typeRegistry holds per-type functions, such as custom marshalers, keyed by the element type of Nullable.
+type typeRegistry struct {
+	mu    sync.RWMutex
+	funcs map[reflect.Type]any
+}
+
+// register stores the function f for type T, or removes the entry when f is a nil function.
+func register[T any](r *typeRegistry, f any) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if reflect.ValueOf(f).IsNil() {
+		delete(r.funcs, t)
+		return
+	}
+	if r.funcs == nil {
+		r.funcs = map[reflect.Type]any{}
+	}
+	r.funcs[t] = f
+}
+
+// lookup returns the function registered for type T, if any.
+func lookup[T any, F any](r *typeRegistry) (F, bool) {
+	f, ok := r.get(reflect.TypeOf((*T)(nil)).Elem()).(F)
+	return f, ok
+}
+
+// get returns the function registered for type t, or nil.
+func (r *typeRegistry) get(t reflect.Type) any {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.funcs[t]
+}
+
+var marshalers typeRegistry
+
+// RegisterMarshaler registers a function used by MarshalJSON to encode valid Nullable[T] values,
+// which customizes the JSON output of types that can't be given a MarshalJSON method, e.g. types from other packages.
+// Invalid values are still encoded as null. Passing nil removes the registration. It is safe for concurrent use.
+func RegisterMarshaler[T any](marshal func(T) ([]byte, error)) {
+	register[T](&marshalers, marshal)
+}
+
+var converters typeRegistry
+
+// RegisterConverter registers a function that Scan uses to convert driver values into T before trying the built-in
+// conversions, e.g. to parse a "lat,lng" column into a struct. The converter can return an error wrapping
+// ErrUnsupportedConversion to let the built-in conversions handle the value instead. The converter also applies
+// to the elements of arrays scanned into slices of T.
+// NULL values never reach the converter. Passing nil removes the registration. It is safe for concurrent use.
+func RegisterConverter[T any](convert func(value any) (T, error)) {
+	register[T](&converters, convert)
+	registerElementType[T]()
+}
+
+var normalizers typeRegistry
+
+// RegisterNormalizer registers a function that Scan and the unmarshal methods, e.g. UnmarshalJSON, apply
+// to valid Nullable[T] values before storing them, which centralizes cleanup such as trimming, casing or rounding.
+// Null values are never normalized.
+// Passing nil removes the registration. It is safe for concurrent use.
+func RegisterNormalizer[T any](normalize func(T) T) {
+	register[T](&normalizers, normalize)
+}
+
+// normalize applies the normalizer registered for T, if any, to value.
+func normalize[T any](value T) T {
+	if f, ok := lookup[T, func(T) T](&normalizers); ok {
+		return f(value)
+	}
+	return value
+}
@@ -0,0 +1,596 @@
+package gonull
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetIntegerTimeFormat(t *testing.T) {
+	defer SetIntegerTimeFormat(nil)
+
+	t.Run("disabled by default", func(t *testing.T) {
+		var n Nullable[time.Time]
+		err := n.Scan(int64(19000))
+		assert.ErrorIs(t, err, ErrUnsupportedConversion)
+		assert.False(t, n.Valid)
+	})
+
+	t.Run("days since unix epoch", func(t *testing.T) {
+		SetIntegerTimeFormat(&UnixDays)
+
+		var n Nullable[time.Time]
+		err := n.Scan(int64(19000))
+		assert.NoError(t, err)
+		assert.True(t, n.Valid)
+		assert.Equal(t, time.Date(2022, time.January, 8, 0, 0, 0, 0, time.UTC), n.Val)
+	})
+
+	t.Run("julian day", func(t *testing.T) {
+		SetIntegerTimeFormat(&JulianDays)
+
+		var n Nullable[time.Time]
+		err := n.Scan(int64(2451545))
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC), n.Val)
+	})
+
+	t.Run("milliseconds", func(t *testing.T) {
+		SetIntegerTimeFormat(&UnixMillis)
+
+		var n Nullable[time.Time]
+		err := n.Scan(int64(1700000000123))
+		assert.NoError(t, err)
+		assert.Equal(t, time.UnixMilli(1700000000123).UTC(), n.Val)
+	})
+
+	t.Run("custom epoch in seconds", func(t *testing.T) {
+		epoch := time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC)
+		SetIntegerTimeFormat(&IntegerTimeFormat{Epoch: epoch, Unit: time.Second})
+
+		var n Nullable[time.Time]
+		err := n.Scan(int64(-60))
+		assert.NoError(t, err)
+		assert.Equal(t, epoch.Add(-time.Minute), n.Val)
+	})
+
+	t.Run("time values are unaffected", func(t *testing.T) {
+		SetIntegerTimeFormat(&UnixDays)
+		now := time.Now()
+
+		var n Nullable[time.Time]
+		err := n.Scan(now)
+		assert.NoError(t, err)
+		assert.Equal(t, now, n.Val)
+	})
+}
+
+func TestSetBoolValueFormat(t *testing.T) {
+	defer SetBoolValueFormat(nil)
+
+	tests := []struct {
+		name      string
+		format    *BoolText
+		wantTrue  any
+		wantFalse any
+	}{
+		{name: "native by default", format: nil, wantTrue: true, wantFalse: false},
+		{name: "t/f", format: &BoolTextTF, wantTrue: "t", wantFalse: "f"},
+		{name: "1/0", format: &BoolTextDigits, wantTrue: "1", wantFalse: "0"},
+		{name: "true/false", format: &BoolTextWords, wantTrue: "true", wantFalse: "false"},
+		{name: "custom", format: &BoolText{True: "on", False: "off"}, wantTrue: "on", wantFalse: "off"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetBoolValueFormat(tt.format)
+
+			value, err := NewNullable(true).Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTrue, value)
+
+			value, err = NewNullable(false).Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantFalse, value)
+
+			value, err = Nullable[bool]{Present: true}.Value()
+			assert.NoError(t, err)
+			assert.Nil(t, value)
+		})
+	}
+}
+
+func TestSetTimeStringLayout(t *testing.T) {
+	defer SetTimeStringLayout("")
+
+	ts := time.Date(2024, time.February, 29, 13, 45, 0, 0, time.FixedZone("UTC+4", 4*60*60))
+
+	var n Nullable[string]
+	err := n.Scan(ts)
+	assert.NoError(t, err)
+	assert.True(t, n.Valid)
+	assert.Equal(t, "2024-02-29T13:45:00+04:00", n.Val)
+
+	SetTimeStringLayout(time.DateTime)
+	err = n.Scan(ts)
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-02-29 13:45:00", n.Val)
+
+	SetTimeStringLayout("")
+	err = n.Scan(ts)
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-02-29T13:45:00+04:00", n.Val)
+}
+
+func TestRegisterTimeLayouts(t *testing.T) {
+	defer RegisterTimeLayouts()
+
+	t.Run("defaults", func(t *testing.T) {
+		tests := []struct {
+			value any
+			want  time.Time
+		}{
+			{value: "2024-05-01T10:20:30Z", want: time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC)},
+			{value: []byte("2024-05-01 10:20:30"), want: time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC)},
+			{value: "2024-05-01", want: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		}
+
+		for _, tt := range tests {
+			var n Nullable[time.Time]
+			err := n.Scan(tt.value)
+			assert.NoError(t, err)
+			assert.True(t, n.Valid)
+			assert.True(t, tt.want.Equal(n.Val), "got %s", n.Val)
+		}
+
+		var n Nullable[time.Time]
+		err := n.Scan("01/05/2024")
+		assert.Error(t, err)
+		assert.False(t, n.Valid)
+	})
+
+	t.Run("custom layout", func(t *testing.T) {
+		RegisterTimeLayouts(append(TimeLayouts(), "02/01/2006")...)
+
+		var n Nullable[time.Time]
+		err := n.Scan("01/05/2024")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), n.Val)
+
+		err = n.Scan("2024-05-01T10:20:30Z")
+		assert.NoError(t, err)
+	})
+
+	t.Run("replace layouts", func(t *testing.T) {
+		RegisterTimeLayouts("02/01/2006")
+		assert.Equal(t, []string{"02/01/2006"}, TimeLayouts())
+
+		var n Nullable[time.Time]
+		err := n.Scan("2024-05-01T10:20:30Z")
+		assert.Error(t, err)
+
+		RegisterTimeLayouts()
+		assert.Equal(t, []string{time.RFC3339, time.DateTime, time.DateOnly}, TimeLayouts())
+	})
+
+	t.Run("concurrent use", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				RegisterTimeLayouts(append(TimeLayouts(), time.Kitchen)...)
+			}()
+			go func() {
+				defer wg.Done()
+				var n Nullable[time.Time]
+				_ = n.Scan("2024-05-01")
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestSetEmptyTimeAsNull(t *testing.T) {
+	defer SetEmptyTimeAsNull(false)
+
+	var n Nullable[time.Time]
+	assert.Error(t, n.Scan(""))
+	assert.False(t, n.Valid)
+
+	SetEmptyTimeAsNull(true)
+	n = NewNullable(time.Now())
+	assert.NoError(t, n.Scan(""))
+	assert.Equal(t, Nullable[time.Time]{Present: true}, n)
+
+	assert.NoError(t, n.Scan([]byte{}))
+	assert.True(t, n.Present)
+	assert.False(t, n.Valid)
+
+	assert.NoError(t, n.Scan("2024-05-01"))
+	assert.True(t, n.Valid)
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), n.Val)
+
+	var s Nullable[string]
+	assert.NoError(t, s.Scan(""))
+	assert.Equal(t, NewNullable(""), s)
+}
+
+func TestSetMaxStringLength(t *testing.T) {
+	defer SetMaxStringLength(0, StringLengthError)
+
+	t.Run("disabled by default", func(t *testing.T) {
+		var n Nullable[string]
+		err := n.Scan("a rather long value")
+		assert.NoError(t, err)
+		assert.Equal(t, "a rather long value", n.Val)
+	})
+
+	t.Run("error mode", func(t *testing.T) {
+		SetMaxStringLength(5, StringLengthError)
+
+		var n Nullable[string]
+		err := n.Scan("გამარჯობა")
+		assert.ErrorIs(t, err, ErrStringTooLong)
+		assert.False(t, n.Valid)
+		assert.True(t, n.Present)
+
+		err = n.Scan("ok")
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", n.Val)
+
+		err = n.Scan("exact")
+		assert.NoError(t, err)
+		assert.Equal(t, "exact", n.Val)
+	})
+
+	t.Run("truncate mode", func(t *testing.T) {
+		SetMaxStringLength(5, StringLengthTruncate)
+
+		var n Nullable[string]
+		err := n.Scan("გამარჯობა")
+		assert.NoError(t, err)
+		assert.True(t, n.Valid)
+		assert.Equal(t, "გამარ", n.Val)
+
+		err = n.Scan(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Equal(t, "2024-", n.Val)
+	})
+}
+
+func TestSetStringByteDecoder(t *testing.T) {
+	defer SetStringByteDecoder(nil)
+
+	// "Hé€" as UTF-16LE with a byte order mark, as returned for NVARCHAR columns.
+	utf16le := []byte{0xff, 0xfe, 'H', 0x00, 0xe9, 0x00, 0xac, 0x20}
+
+	// By default the bytes are converted as is.
+	var n Nullable[string]
+	err := n.Scan([]byte("plain text"))
+	assert.NoError(t, err)
+	assert.Equal(t, NewNullable("plain text"), n)
+	err = n.Scan(utf16le)
+	assert.NoError(t, err)
+	assert.Equal(t, string(utf16le), n.Val)
+
+	SetStringByteDecoder(DecodeUTF16LE)
+	err = n.Scan(utf16le)
+	assert.NoError(t, err)
+	assert.True(t, n.Valid)
+	assert.Equal(t, "Hé€", n.Val)
+
+	err = n.Scan([]byte{'H', 0x00, 'i'})
+	assert.ErrorIs(t, err, ErrOddUTF16Length)
+	assert.False(t, n.Valid)
+
+	SetStringByteDecoder(DecodeLatin1)
+	err = n.Scan([]byte{'c', 'a', 'f', 0xe9})
+	assert.NoError(t, err)
+	assert.Equal(t, "café", n.Val)
+
+	err = n.Scan("plain")
+	assert.NoError(t, err)
+	assert.Equal(t, "plain", n.Val)
+}
+
+// upperConverter stands in for a driver-specific converter that stores strings in upper case.
+type upperConverter struct{}
+
+func (upperConverter) ConvertValue(v any) (driver.Value, error) {
+	if s, ok := v.(string); ok {
+		return strings.ToUpper(s), nil
+	}
+	if _, ok := v.(bool); ok {
+		return nil, errors.New("booleans are not supported")
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+func TestSetValueConverter(t *testing.T) {
+	defer SetValueConverter(nil)
+
+	SetValueConverter(upperConverter{})
+
+	value, err := NewNullable("abc").Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "ABC", value)
+
+	value, err = NewNullable(int32(5)).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), value)
+
+	_, err = NewNullable(true).Value()
+	assert.EqualError(t, err, "booleans are not supported")
+
+	// ValueContext applies the converter the same way.
+	value, err = NewNullable("abc").ValueContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "ABC", value)
+
+	value, err = Nullable[string]{Present: true}.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	SetValueConverter(nil)
+	value, err = NewNullable("abc").Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", value)
+}
+
+func TestSetScanTimeLocation(t *testing.T) {
+	defer SetScanTimeLocation(nil)
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	scanned := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	var n Nullable[time.Time]
+	assert.NoError(t, n.Scan(scanned))
+	assert.Equal(t, time.UTC, n.Val.Location())
+
+	SetScanTimeLocation(tokyo)
+	assert.NoError(t, n.Scan(scanned))
+	assert.True(t, n.Valid)
+	assert.Equal(t, tokyo, n.Val.Location())
+	assert.Equal(t, 21, n.Val.Hour())
+	assert.True(t, scanned.Equal(n.Val))
+
+	assert.NoError(t, n.Scan("2024-05-01T12:00:00Z"))
+	assert.Equal(t, tokyo, n.Val.Location())
+
+	var s Nullable[string]
+	assert.NoError(t, s.Scan(scanned))
+	assert.Equal(t, "2024-05-01T12:00:00Z", s.Val)
+}
+
+func TestSetMaxReaderSize(t *testing.T) {
+	defer SetMaxReaderSize(0)
+
+	var blob Nullable[[]byte]
+	assert.NoError(t, blob.Scan(bytes.NewReader([]byte{0x00, 0xff, 0x10})))
+	assert.True(t, blob.Valid)
+	assert.Equal(t, []byte{0x00, 0xff, 0x10}, blob.Val)
+
+	var text Nullable[string]
+	assert.NoError(t, text.Scan(strings.NewReader("large object")))
+	assert.Equal(t, NewNullable("large object"), text)
+
+	assert.NoError(t, text.Scan((*bytes.Reader)(nil)))
+	assert.True(t, text.Present)
+	assert.False(t, text.Valid)
+
+	SetMaxReaderSize(5)
+	assert.NoError(t, text.Scan(strings.NewReader("exact")))
+	assert.Equal(t, "exact", text.Val)
+
+	err := text.Scan(strings.NewReader("too long"))
+	assert.ErrorIs(t, err, ErrReaderTooLarge)
+	assert.False(t, text.Valid)
+
+	var n Nullable[int]
+	assert.ErrorIs(t, n.Scan(strings.NewReader("1")), ErrUnsupportedConversion)
+}
+
+func TestSetBoolStringFormat(t *testing.T) {
+	defer SetBoolStringFormat(nil)
+
+	var n Nullable[string]
+	assert.NoError(t, n.Scan(true))
+	assert.Equal(t, NewNullable("true"), n)
+	assert.NoError(t, n.Scan(false))
+	assert.Equal(t, NewNullable("false"), n)
+
+	SetBoolStringFormat(&BoolText{True: "Y", False: "N"})
+	assert.NoError(t, n.Scan(true))
+	assert.Equal(t, "Y", n.Val)
+	assert.NoError(t, n.Scan(false))
+	assert.Equal(t, "N", n.Val)
+
+	SetBoolStringFormat(nil)
+	assert.NoError(t, n.Scan(true))
+	assert.Equal(t, "true", n.Val)
+}
+
+func TestSetAmbiguousOrder(t *testing.T) {
+	defer SetAmbiguousOrder(AmbiguousRaw)
+
+	var n Nullable[any]
+	assert.NoError(t, n.Scan([]byte("42")))
+	assert.Equal(t, []byte("42"), n.Val)
+
+	SetAmbiguousOrder(AmbiguousNumericFirst)
+	assert.NoError(t, n.Scan([]byte("42")))
+	assert.Equal(t, int64(42), n.Val)
+	assert.NoError(t, n.Scan([]byte("4.2")))
+	assert.Equal(t, 4.2, n.Val)
+	assert.NoError(t, n.Scan([]byte("forty-two")))
+	assert.Equal(t, "forty-two", n.Val)
+
+	SetAmbiguousOrder(AmbiguousStringFirst)
+	assert.NoError(t, n.Scan([]byte("42")))
+	assert.Equal(t, "42", n.Val)
+
+	var s Nullable[string]
+	assert.NoError(t, s.Scan("42"))
+	assert.Equal(t, "42", s.Val)
+}
+
+func TestSetRecordScannedType(t *testing.T) {
+	defer SetRecordScannedType(false)
+
+	var n Nullable[int]
+	assert.NoError(t, n.Scan(int64(1)))
+	assert.Nil(t, n.ScannedType())
+	assert.Equal(t, NewNullable(1), n)
+
+	SetRecordScannedType(true)
+	var recorded Nullable[int]
+	assert.Nil(t, recorded.ScannedType())
+
+	assert.NoError(t, recorded.Scan([]byte("12")))
+	assert.Equal(t, reflect.TypeOf([]byte(nil)), recorded.ScannedType())
+	assert.Equal(t, 12, recorded.Val)
+
+	assert.Error(t, recorded.Scan(struct{}{}))
+	assert.Equal(t, reflect.TypeOf(struct{}{}), recorded.ScannedType())
+
+	assert.NoError(t, recorded.Scan(nil))
+	assert.Nil(t, recorded.ScannedType())
+}
+
+func TestRegisterMarshaler(t *testing.T) {
+	defer RegisterMarshaler[time.Duration](nil)
+
+	RegisterMarshaler(func(d time.Duration) ([]byte, error) {
+		return json.Marshal(d.String())
+	})
+
+	data, err := json.Marshal(struct {
+		Timeout Nullable[time.Duration] `json:"timeout"`
+		Retry   Nullable[time.Duration] `json:"retry"`
+		Count   Nullable[int64]         `json:"count"`
+	}{
+		Timeout: NewNullable(90 * time.Second),
+		Retry:   Nullable[time.Duration]{Present: true},
+		Count:   NewNullable[int64](3),
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"timeout":"1m30s","retry":null,"count":3}`, string(data))
+
+	RegisterMarshaler[time.Duration](nil)
+	data, err = NewNullable(time.Second).MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, "1000000000", string(data))
+}
+
+func TestRegisterNormalizer(t *testing.T) {
+	RegisterNormalizer(strings.TrimSpace)
+	defer RegisterNormalizer[string](nil)
+
+	var n Nullable[string]
+	assert.NoError(t, n.Scan("  padded\t"))
+	assert.Equal(t, NewNullable("padded"), n)
+
+	assert.NoError(t, json.Unmarshal([]byte(`" from json "`), &n))
+	assert.Equal(t, NewNullable("from json"), n)
+
+	assert.NoError(t, n.Scan(nil))
+	assert.Equal(t, Nullable[string]{Present: true}, n)
+
+	// Other instantiations are not affected.
+	var code Nullable[[]byte]
+	assert.NoError(t, code.Scan([]byte(" x ")))
+	assert.Equal(t, []byte(" x "), code.Val)
+
+	RegisterNormalizer[string](nil)
+	assert.NoError(t, n.Scan(" kept "))
+	assert.Equal(t, " kept ", n.Val)
+}
+
+// geoPoint is stored as "lat,lng" text.
+type geoPoint struct {
+	Lat, Lng float64
+}
+
+func parseGeoPoint(value any) (geoPoint, error) {
+	text, ok := value.(string)
+	if !ok {
+		return geoPoint{}, ErrUnsupportedConversion
+	}
+	var p geoPoint
+	if _, err := fmt.Sscanf(text, "%g,%g", &p.Lat, &p.Lng); err != nil {
+		return geoPoint{}, fmt.Errorf("invalid point %q: %w", text, err)
+	}
+	return p, nil
+}
+
+func TestRegisterConverter(t *testing.T) {
+	RegisterConverter(parseGeoPoint)
+	defer RegisterConverter[geoPoint](nil)
+
+	var n Nullable[geoPoint]
+	assert.NoError(t, n.Scan("41.7151,44.8271"))
+	assert.Equal(t, NewNullable(geoPoint{Lat: 41.7151, Lng: 44.8271}), n)
+
+	err := n.Scan("nowhere")
+	assert.ErrorContains(t, err, `invalid point "nowhere"`)
+	assert.False(t, n.Valid)
+
+	assert.NoError(t, n.Scan(nil))
+	assert.Equal(t, Nullable[geoPoint]{Present: true}, n)
+
+	var points Nullable[[]geoPoint]
+	assert.NoError(t, points.Scan(`{"41.7151,44.8271","0,0"}`))
+	assert.Equal(t, []geoPoint{{Lat: 41.7151, Lng: 44.8271}, {}}, points.Val)
+	assert.NoError(t, points.Scan([]any{"1,2"}))
+	assert.Equal(t, []geoPoint{{Lat: 1, Lng: 2}}, points.Val)
+
+	// Unsupported values fall back to the built-in conversions, here JSON.
+	assert.NoError(t, n.Scan([]byte(`{"Lat":1,"Lng":2}`)))
+	assert.Equal(t, geoPoint{Lat: 1, Lng: 2}, n.Val)
+
+	RegisterConverter[geoPoint](nil)
+	assert.Error(t, n.Scan("41.7151,44.8271"))
+}
+
+func TestSetOnScanError(t *testing.T) {
+	defer SetOnScanError(nil)
+
+	var gotTarget reflect.Type
+	var gotValue any
+	SetOnScanError(func(target reflect.Type, value any) error {
+		gotTarget, gotValue = target, value
+		return nil
+	})
+
+	n := NewNullable("stale")
+	err := n.Scan(int64(42))
+	assert.NoError(t, err)
+	assert.False(t, n.Valid)
+	assert.True(t, n.Present)
+	assert.Equal(t, "", n.Val)
+	assert.Equal(t, reflect.TypeOf(""), gotTarget)
+	assert.Equal(t, int64(42), gotValue)
+
+	errLenient := errors.New("lenient")
+	SetOnScanError(func(target reflect.Type, value any) error {
+		return fmt.Errorf("%w: %s from %T", errLenient, target, value)
+	})
+	err = n.Scan(int64(42))
+	assert.ErrorIs(t, err, errLenient)
+	assert.EqualError(t, err, "lenient: string from int64")
+
+	err = n.Scan("converted")
+	assert.NoError(t, err)
+	assert.True(t, n.Valid)
+}
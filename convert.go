@@ -0,0 +1,934 @@
+package gonull
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// convertWithParser parses a string or []byte driver value with the Parse method of T, if *T implements Parser.
+// The returned bool reports whether the conversion applies.
+func convertWithParser[T any](value any) (T, bool, error) {
+	var zero T
+
+	parser, ok := any(&zero).(Parser)
+	if !ok {
+		return zero, false, nil
+	}
+	text, ok := asText(value)
+	if !ok {
+		return zero, false, nil
+	}
+
+	if err := parser.Parse(text); err != nil {
+		return zeroValue[T](), true, err
+	}
+	return zero, true, nil
+}
+
+// convertToKnownType converts driver values into well-known target types that have no generic reflection path.
+// The returned bool reports whether T is one of the handled types:
+//   - net.IP, net.HardwareAddr and *net.IPNet are parsed from their text form.
+//   - json.RawMessage copies string and []byte values.
+//   - *big.Rat is read from int64 values and exact rational text.
+//   - string formats time.Time, bool and fmt.Stringer values, and decodes []byte with SetStringByteDecoder
+//     or converts it as is.
+//   - time.Time parses text with the registered layouts, and integers when SetIntegerTimeFormat is used.
+func convertToKnownType[T any](value any) (T, bool, error) {
+	var zero T
+
+	switch target := any(&zero).(type) {
+	case *net.HardwareAddr:
+		text, ok := asText(value)
+		if !ok {
+			return zero, true, ErrUnsupportedConversion
+		}
+		mac, err := net.ParseMAC(text)
+		if err != nil {
+			return zero, true, err
+		}
+		*target = mac
+
+	case *net.IP:
+		// Only textual addresses are parsed. Anything else is left to convertToNamedBytes, e.g. raw 4 or 16 bytes.
+		text, ok := asText(value)
+		if !ok {
+			return zero, false, nil
+		}
+		ip := net.ParseIP(text)
+		if ip == nil {
+			return zero, false, nil
+		}
+		*target = ip
+
+	case **net.IPNet:
+		text, ok := asText(value)
+		if !ok {
+			return zero, true, ErrUnsupportedConversion
+		}
+		_, ipNet, err := net.ParseCIDR(text)
+		if err != nil {
+			return zero, true, err
+		}
+		*target = ipNet
+
+	case *json.RawMessage:
+		switch v := value.(type) {
+		case []byte:
+			// Drivers may reuse the buffer on the next call to Next, so the raw bytes are copied.
+			*target = append(json.RawMessage{}, v...)
+		case string:
+			*target = json.RawMessage(v)
+		default:
+			return zero, true, ErrUnsupportedConversion
+		}
+
+	case **big.Rat:
+		if i, ok := value.(int64); ok {
+			*target = new(big.Rat).SetInt64(i)
+			break
+		}
+		text, ok := asText(value)
+		if !ok {
+			return zero, true, ErrUnsupportedConversion
+		}
+		rat, ok := new(big.Rat).SetString(text)
+		if !ok {
+			return zero, true, fmt.Errorf("invalid rational number: %q", text)
+		}
+		*target = rat
+
+	case *string:
+		switch v := value.(type) {
+		case time.Time:
+			*target = v.Format(loadSettings().timeLayout)
+		case bool:
+			*target = loadSettings().boolString.text(v)
+		case fmt.Stringer:
+			// Some drivers return their own scalar wrappers, which can still be read through String.
+			*target = v.String()
+		case []byte:
+			decode := loadSettings().byteDecoder
+			if decode == nil {
+				*target = string(v)
+				break
+			}
+			text, err := decode(v)
+			if err != nil {
+				return zero, true, err
+			}
+			*target = text
+		default:
+			return zero, false, nil
+		}
+
+	case *time.Time:
+		if text, ok := asText(value); ok {
+			t, err := parseTime(text)
+			if err != nil {
+				return zero, true, err
+			}
+			*target = t
+			break
+		}
+
+		format := loadSettings().integerTime
+		i, ok := value.(int64)
+		if format == nil || !ok {
+			return zero, false, nil
+		}
+		*target = format.timeFromInteger(i)
+
+	default:
+		return zero, false, nil
+	}
+
+	return zero, true, nil
+}
+
+// parseTime parses text with the layouts registered with RegisterTimeLayouts, in order.
+func parseTime(text string) (time.Time, error) {
+	for _, layout := range loadSettings().timeLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as time.Time", text)
+}
+
+// asText returns the given driver value as a string if it is a string or a []byte.
+func asText(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	default:
+		return "", false
+	}
+}
+
+// convertDecoded converts a value produced by a generic decoder, such as a TOML document or a GraphQL input, to T.
+// Lists and tables are decoded into []any and map[string]any, so when convertToType can't handle them
+// they are converted through JSON.
+func convertDecoded[T any](value any) (T, error) {
+	converted, err := convertToType[T](value)
+	if !errors.Is(err, ErrUnsupportedConversion) {
+		return converted, err
+	}
+
+	converted = zeroValue[T]()
+	encoded, err := json.Marshal(value)
+	if err == nil {
+		err = json.Unmarshal(encoded, &converted)
+	}
+	return converted, err
+}
+
+// convertFromJSON decodes JSON text into composite target types, which is how drivers return JSON/JSONB columns.
+// It applies when T is a struct, map or non-byte slice, or implements json.Unmarshaler, and the driver value
+// is a string or []byte holding a JSON object or array, or an already decoded map[string]any.
+// The returned bool reports whether the conversion was attempted.
+func convertFromJSON[T any](value any) (T, bool, error) {
+	var zero T
+
+	if _, ok := any(&zero).(json.Unmarshaler); !ok && !isJSONComposite(reflect.TypeOf(zero)) {
+		return zero, false, nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case map[string]any:
+		// Some drivers decode JSON objects themselves, so the map is encoded again to decode it into T.
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return zero, true, err
+		}
+		data = encoded
+	default:
+		return zero, false, nil
+	}
+
+	if !looksLikeJSON(data) {
+		return zero, false, nil
+	}
+
+	if err := json.Unmarshal(data, &zero); err != nil {
+		return zero, true, err
+	}
+	return zero, true, nil
+}
+
+// convertFromPostgresArray converts the text output of a one-dimensional PostgreSQL array, e.g. `{active,inactive}`,
+// into a non-byte slice target. Each element is converted into the element type: sql.Scanner implementations,
+// such as Nullable, scan the element text, while string, bool and numeric kinds, including named types, are parsed.
+// Unquoted NULL elements become the zero value of the element type unless it is a sql.Scanner, which scans nil.
+// The returned bool reports whether the conversion was attempted.
+func convertFromPostgresArray[T any](value any) (T, bool, error) {
+	var zero T
+
+	targetType := reflect.TypeOf(zero)
+	if targetType == nil || targetType.Kind() != reflect.Slice || targetType.Elem().Kind() == reflect.Uint8 {
+		return zero, false, nil
+	}
+	// Types decoding their own JSON are left to convertFromJSON, since `{...}` may be a JSON object for them.
+	if _, ok := any(&zero).(json.Unmarshaler); ok {
+		return zero, false, nil
+	}
+
+	text, ok := asText(value)
+	if !ok {
+		return zero, false, nil
+	}
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "{") || !strings.HasSuffix(text, "}") {
+		return zero, false, nil
+	}
+
+	elements, err := parsePostgresArray(text)
+	if err != nil {
+		return zero, true, err
+	}
+
+	slice := reflect.MakeSlice(targetType, len(elements), len(elements))
+	for i, element := range elements {
+		if err := setArrayElement(slice.Index(i), element); err != nil {
+			return zero, true, fmt.Errorf("array element %d: %w", i, err)
+		}
+	}
+	return slice.Interface().(T), true, nil
+}
+
+// parsePostgresArray splits the text output of a one-dimensional PostgreSQL array into its elements.
+// Quoted elements are unescaped and unquoted NULL elements are returned as nil.
+func parsePostgresArray(text string) ([]*string, error) {
+	body := text[1 : len(text)-1]
+	if strings.TrimSpace(body) == "" {
+		return []*string{}, nil
+	}
+
+	var elements []*string
+	for i := 0; ; {
+		var element strings.Builder
+		quoted := false
+
+		if i < len(body) && body[i] == '"' {
+			quoted = true
+			for i++; ; i++ {
+				if i >= len(body) {
+					return nil, fmt.Errorf("unterminated quoted element in array %q", text)
+				}
+				if body[i] == '\\' && i+1 < len(body) {
+					i++
+				} else if body[i] == '"' {
+					i++
+					break
+				}
+				element.WriteByte(body[i])
+			}
+		} else {
+			for ; i < len(body) && body[i] != ','; i++ {
+				if body[i] == '{' || body[i] == '"' {
+					return nil, fmt.Errorf("multidimensional or malformed array %q is not supported", text)
+				}
+				element.WriteByte(body[i])
+			}
+		}
+
+		value := element.String()
+		if quoted {
+			elements = append(elements, &value)
+		} else if value = strings.TrimSpace(value); strings.EqualFold(value, "NULL") {
+			elements = append(elements, nil)
+		} else {
+			elements = append(elements, &value)
+		}
+
+		if i >= len(body) {
+			return elements, nil
+		}
+		if body[i] != ',' {
+			return nil, fmt.Errorf("malformed array %q", text)
+		}
+		i++
+	}
+}
+
+// convertFromAnySlice converts a []any, as drivers decode composite and array columns into, into a slice target
+// by converting each element into the element type with setElement.
+// The returned bool reports whether the conversion applies.
+func convertFromAnySlice[T any](value any) (T, bool, error) {
+	var zero T
+
+	elements, ok := value.([]any)
+	if !ok {
+		return zero, false, nil
+	}
+	targetType := reflect.TypeOf(zero)
+	if targetType == nil || targetType.Kind() != reflect.Slice {
+		return zero, false, nil
+	}
+
+	slice := reflect.MakeSlice(targetType, len(elements), len(elements))
+	if err := setAnySlice(slice, elements); err != nil {
+		return zero, true, err
+	}
+	return slice.Interface().(T), true, nil
+}
+
+// setAnySlice converts elements into the elements of slice, which must have the same length.
+func setAnySlice(slice reflect.Value, elements []any) error {
+	for i, element := range elements {
+		if err := setElement(slice.Index(i), element); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// setArrayElement converts a single array element into target, which must be settable. NULL elements are nil.
+func setArrayElement(target reflect.Value, element *string) error {
+	if element == nil {
+		return setElement(target, nil)
+	}
+	return setElement(target, *element)
+}
+
+// elementTypes holds, for types that convertToType handles beyond their kind, a function that converts
+// a value with convertToType for that type. setElement uses them, since element types are only known at run time.
+var elementTypes typeRegistry
+
+func init() {
+	registerElementType[time.Time]()
+	registerElementType[net.IP]()
+	registerElementType[net.HardwareAddr]()
+	registerElementType[*net.IPNet]()
+	registerElementType[json.RawMessage]()
+	registerElementType[*big.Rat]()
+}
+
+// registerElementType makes setElement convert elements of type T with convertToType.
+func registerElementType[T any]() {
+	register[T](&elementTypes, func(value any) (any, error) {
+		return convertToType[T](value)
+	})
+}
+
+// setElement converts a single element of an array or slice driver value into target, which must be settable,
+// the same way convertToType converts whole values:
+//   - sql.Scanner implementations scan the element, including nil.
+//   - Well-known types and types with a registered converter are converted with convertToType.
+//   - Parser implementations parse textual elements.
+//   - Assignable and numeric values are converted directly.
+//   - Nested []any values are converted into slice targets recursively.
+//   - Text is converted into string, bool and numeric kinds.
+//
+// A nil element leaves the zero value.
+func setElement(target reflect.Value, element any) error {
+	if scanner, ok := target.Addr().Interface().(sql.Scanner); ok {
+		return scanner.Scan(element)
+	}
+	if element == nil {
+		return nil
+	}
+
+	if convert, ok := elementTypes.get(target.Type()).(func(any) (any, error)); ok {
+		converted, err := convert(element)
+		if err != nil {
+			return err
+		}
+		target.Set(reflect.ValueOf(converted))
+		return nil
+	}
+
+	if parser, ok := target.Addr().Interface().(Parser); ok {
+		if text, ok := asText(element); ok {
+			return parser.Parse(text)
+		}
+	}
+
+	rv := reflect.ValueOf(element)
+	switch {
+	case rv.Type().AssignableTo(target.Type()):
+		target.Set(rv)
+		return nil
+	case isNumericKind(rv.Type()) && isNumericKind(target.Type()):
+		converted, err := convertNumeric(rv, target.Type())
+		if err != nil {
+			return err
+		}
+		target.Set(converted)
+		return nil
+	case target.Kind() == reflect.Slice:
+		if nested, ok := element.([]any); ok {
+			slice := reflect.MakeSlice(target.Type(), len(nested), len(nested))
+			if err := setAnySlice(slice, nested); err != nil {
+				return err
+			}
+			target.Set(slice)
+			return nil
+		}
+	}
+
+	text, ok := asText(element)
+	if !ok {
+		return ErrUnsupportedConversion
+	}
+	switch kind := target.Kind(); {
+	case kind == reflect.String:
+		target.SetString(text)
+	case kind == reflect.Bool:
+		b, err := parseBoolText(text)
+		if err != nil {
+			return err
+		}
+		target.SetBool(b)
+	case isNumericKind(target.Type()):
+		return setNumericText(target, text)
+	default:
+		return ErrUnsupportedConversion
+	}
+	return nil
+}
+
+// isJSONComposite reports whether t is a type that is commonly stored as a JSON document.
+func isJSONComposite(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() != reflect.Uint8
+	default:
+		return false
+	}
+}
+
+// looksLikeJSON reports whether data holds a JSON object or array.
+func looksLikeJSON(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) < 2 {
+		return false
+	}
+
+	first, last := trimmed[0], trimmed[len(trimmed)-1]
+	return (first == '{' && last == '}') || (first == '[' && last == ']')
+}
+
+// convertFromBytea decodes PostgreSQL bytea text output into a []byte target when SetByteaDecoding is enabled.
+// Both the hex format (`\x0102`) and the legacy escape format (`\001\002`) are recognized.
+// Any other string or []byte is taken as raw bytes.
+// The returned bool reports whether decoding is enabled, T is []byte and the value is textual.
+func convertFromBytea[T any](value any) (T, bool, error) {
+	var zero T
+
+	target, ok := any(&zero).(*[]byte)
+	if !ok || !loadSettings().byteaDecoding {
+		return zero, false, nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return zero, false, nil
+	}
+
+	if bytes.HasPrefix(data, []byte(`\x`)) {
+		decoded := make([]byte, hex.DecodedLen(len(data)-2))
+		if _, err := hex.Decode(decoded, data[2:]); err != nil {
+			return zero, true, fmt.Errorf("invalid bytea hex format: %w", err)
+		}
+		*target = decoded
+		return zero, true, nil
+	}
+
+	if decoded, ok := decodeByteaEscape(data); ok {
+		*target = decoded
+		return zero, true, nil
+	}
+
+	*target = data
+	return zero, true, nil
+}
+
+// decodeByteaEscape decodes the bytea escape format, where a backslash is written as `\\` and
+// non-printable bytes as a backslash followed by three octal digits. It returns false if data contains
+// no escape sequences or is not valid escape format.
+func decodeByteaEscape(data []byte) ([]byte, bool) {
+	if bytes.IndexByte(data, '\\') < 0 {
+		return nil, false
+	}
+
+	isOctal := func(b byte) bool { return b >= '0' && b <= '7' }
+
+	decoded := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] != '\\' {
+			decoded = append(decoded, data[i])
+			continue
+		}
+
+		switch {
+		case i+1 < len(data) && data[i+1] == '\\':
+			decoded = append(decoded, '\\')
+			i++
+		case i+3 < len(data) && data[i+1] <= '3' && isOctal(data[i+1]) && isOctal(data[i+2]) && isOctal(data[i+3]):
+			decoded = append(decoded, (data[i+1]-'0')<<6|(data[i+2]-'0')<<3|(data[i+3]-'0'))
+			i += 3
+		default:
+			return nil, false
+		}
+	}
+
+	return decoded, true
+}
+
+// convertToNamedBytes converts a []byte or string driver value into a named byte slice type such as `type Token []byte`,
+// which the exact type match in convertToType doesn't cover. The bytes are copied, since drivers may reuse the buffer.
+// The returned bool reports whether the conversion applies.
+func convertToNamedBytes[T any](value any) (T, bool) {
+	var zero T
+
+	targetType := reflect.TypeOf(zero)
+	if targetType == nil || targetType.Kind() != reflect.Slice || targetType.Elem().Kind() != reflect.Uint8 {
+		return zero, false
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = append([]byte{}, v...)
+	case string:
+		data = []byte(v)
+	default:
+		return zero, false
+	}
+
+	return reflect.ValueOf(data).Convert(targetType).Interface().(T), true
+}
+
+// unwrapSQLNull returns the underlying driver value of the database/sql null types, such as sql.NullTime or
+// sql.NullString, which other scanners may hand to Scan. An invalid sql.NullXxx unwraps to nil.
+// Any other value is returned unchanged.
+func unwrapSQLNull(value any) (any, error) {
+	switch v := value.(type) {
+	case sql.NullString, sql.NullInt64, sql.NullInt32, sql.NullInt16, sql.NullByte,
+		sql.NullFloat64, sql.NullBool, sql.NullTime:
+		return v.(driver.Valuer).Value()
+	default:
+		return value, nil
+	}
+}
+
+// convertFromReader reads a driver value implementing io.Reader, as some drivers return for large objects,
+// into a target whose kind is string or a byte slice. The stream is read fully, up to the limit set by SetMaxReaderSize.
+// The returned bool reports whether the conversion applies.
+func convertFromReader[T any](value any) (T, bool, error) {
+	var zero T
+
+	reader, ok := value.(io.Reader)
+	if !ok {
+		return zero, false, nil
+	}
+
+	target := reflect.ValueOf(&zero).Elem()
+	isBytes := target.Kind() == reflect.Slice && target.Type().Elem().Kind() == reflect.Uint8
+	if target.Kind() != reflect.String && !isBytes {
+		return zero, false, nil
+	}
+
+	limit := loadSettings().maxReaderSize
+	if limit > 0 {
+		// One byte more than the limit is read to tell a stream of exactly limit bytes from a longer one.
+		reader = io.LimitReader(reader, limit+1)
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return zero, true, err
+	}
+	if limit > 0 && int64(len(data)) > limit {
+		return zero, true, fmt.Errorf("%w: more than %d bytes", ErrReaderTooLarge, limit)
+	}
+
+	if isBytes {
+		target.SetBytes(data)
+	} else {
+		target.SetString(string(data))
+	}
+	return zero, true, nil
+}
+
+// convertAmbiguousBytes resolves a []byte scanned into an interface target, such as Nullable[any], according to
+// the order set by SetAmbiguousOrder. A candidate that isn't assignable to T is skipped.
+// The returned bool reports whether a conversion was made.
+func convertAmbiguousBytes[T any](value any) (T, bool) {
+	var zero T
+
+	data, ok := value.([]byte)
+	if !ok {
+		return zero, false
+	}
+
+	var candidates []any
+	switch loadSettings().ambiguousOrder {
+	case AmbiguousNumericFirst:
+		text := string(data)
+		if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+			candidates = append(candidates, i)
+		} else if f, err := strconv.ParseFloat(text, 64); err == nil {
+			candidates = append(candidates, f)
+		}
+		candidates = append(candidates, text)
+	case AmbiguousStringFirst:
+		candidates = append(candidates, string(data))
+	}
+
+	for _, candidate := range candidates {
+		if converted, ok := candidate.(T); ok {
+			return converted, true
+		}
+	}
+	return zero, false
+}
+
+// isEmptyTimeText reports whether value is an empty string or []byte scanned into a time.Time target
+// while SetEmptyTimeAsNull is enabled.
+func isEmptyTimeText[T any](value any) bool {
+	var zero T
+	if _, ok := any(zero).(time.Time); !ok || !loadSettings().emptyTimeAsNull {
+		return false
+	}
+
+	text, ok := asText(value)
+	return ok && text == ""
+}
+
+// isNilPointer reports whether value is a nil pointer of any type.
+func isNilPointer(value any) bool {
+	rv := reflect.ValueOf(value)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
+
+// convertToBool converts the integer and textual forms drivers use for boolean columns, such as MySQL TINYINT(1),
+// into a target whose kind is bool. Integers are true when non-zero, text is parsed by parseBoolText and
+// single raw bytes, as returned for BIT(1) columns, are true when non-zero.
+// The returned bool reports whether the conversion applies.
+func convertToBool[T any](value any) (T, bool, error) {
+	var zero T
+
+	targetType := reflect.TypeOf(zero)
+	if targetType == nil || targetType.Kind() != reflect.Bool {
+		return zero, false, nil
+	}
+
+	var b bool
+	switch v := value.(type) {
+	case int64:
+		b = v != 0
+	case []byte:
+		if len(v) == 1 && v[0] <= 1 {
+			b = v[0] == 1
+			break
+		}
+		parsed, err := parseBoolText(string(v))
+		if err != nil {
+			return zero, true, err
+		}
+		b = parsed
+	case string:
+		parsed, err := parseBoolText(v)
+		if err != nil {
+			return zero, true, err
+		}
+		b = parsed
+	default:
+		return zero, false, nil
+	}
+
+	return reflect.ValueOf(b).Convert(targetType).Interface().(T), true, nil
+}
+
+// convertFromBool converts a bool driver value into a numeric target as 1 or 0, the inverse of convertToBool.
+// The returned bool reports whether the conversion applies.
+func convertFromBool[T any](value any) (T, bool) {
+	var zero T
+
+	b, ok := value.(bool)
+	if !ok || !isNumericKind(reflect.TypeOf(zero)) {
+		return zero, false
+	}
+
+	var i int64
+	if b {
+		i = 1
+	}
+	return reflect.ValueOf(i).Convert(reflect.TypeOf(zero)).Interface().(T), true
+}
+
+// parseBoolText parses the textual forms of a boolean column value: the digits "1" and "0" as well as
+// the words accepted by strconv.ParseBool, such as PostgreSQL's "t"/"f" and "true"/"false".
+func parseBoolText(text string) (bool, error) {
+	b, err := strconv.ParseBool(text)
+	if err != nil {
+		return false, fmt.Errorf("invalid boolean value: %q", text)
+	}
+	return b, nil
+}
+
+// convertFromJSONNumber converts a json.Number, as produced by decoders using UseNumber, into a numeric target.
+// The returned bool reports whether the conversion applies.
+func convertFromJSONNumber[T any](value any) (T, bool, error) {
+	var zero T
+
+	number, ok := value.(json.Number)
+	if !ok || !isNumericKind(reflect.TypeOf(zero)) {
+		return zero, false, nil
+	}
+
+	converted, err := parseNumericText[T](number.String())
+	return converted, true, err
+}
+
+// convertFromNumericText parses a string or []byte driver value into a numeric target of any width,
+// which is how some drivers return DECIMAL/NUMERIC columns or numbers stored as text.
+// Integers may carry a 0x, 0o or 0b base prefix, e.g. "0x1F" for flag columns stored as hex.
+// Values that don't fit in T are reported as errors. The returned bool reports whether the conversion applies.
+func convertFromNumericText[T any](value any) (T, bool, error) {
+	var zero T
+
+	if !isNumericKind(reflect.TypeOf(zero)) {
+		return zero, false, nil
+	}
+
+	text, ok := asText(value)
+	if !ok {
+		return zero, false, nil
+	}
+
+	converted, err := parseNumericText[T](strings.TrimSpace(text))
+	return converted, true, err
+}
+
+// convertNumeric converts the numeric value v to the numeric type target, returning an error wrapping
+// strconv.ErrRange instead of silently wrapping around when the value doesn't fit, e.g. for int32-based enums.
+func convertNumeric(v reflect.Value, target reflect.Type) (reflect.Value, error) {
+	overflows := false
+	probe := reflect.New(target).Elem()
+
+	switch {
+	case v.CanInt():
+		i := v.Int()
+		switch {
+		case probe.CanInt():
+			overflows = probe.OverflowInt(i)
+		case probe.CanUint():
+			overflows = i < 0 || probe.OverflowUint(uint64(i))
+		}
+	case v.CanUint():
+		u := v.Uint()
+		switch {
+		case probe.CanInt():
+			overflows = u > math.MaxInt64 || probe.OverflowInt(int64(u))
+		case probe.CanUint():
+			overflows = probe.OverflowUint(u)
+		}
+	case v.CanFloat():
+		f := v.Float()
+		switch {
+		case probe.CanFloat():
+			overflows = probe.OverflowFloat(f)
+		case math.IsNaN(f) || math.IsInf(f, 0):
+			// Integers have no NaN or infinity, and converting them is implementation-defined in Go.
+			overflows = true
+		case probe.CanInt():
+			overflows = f < math.MinInt64 || f >= math.MaxInt64 || probe.OverflowInt(int64(f))
+		case probe.CanUint():
+			overflows = f < 0 || f >= math.MaxUint64 || probe.OverflowUint(uint64(f))
+		}
+	}
+
+	if overflows {
+		return reflect.Value{}, fmt.Errorf("%w: %v does not fit in %s", strconv.ErrRange, v.Interface(), target)
+	}
+	return v.Convert(target), nil
+}
+
+// isNumericKind reports whether t is an integer or floating point type.
+func isNumericKind(t reflect.Type) bool {
+	return t != nil && t.Kind() >= reflect.Int && t.Kind() <= reflect.Float64
+}
+
+// parseNumericText parses text into the numeric type T, returning an error if it is not a number
+// or doesn't fit in T.
+func parseNumericText[T any](text string) (T, error) {
+	var zero T
+	err := setNumericText(reflect.ValueOf(&zero).Elem(), text)
+	return zero, err
+}
+
+// setNumericText parses text into the settable numeric value target.
+func setNumericText(target reflect.Value, text string) error {
+	switch target.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(text, integerBase(text), target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(text, integerBase(text), target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetFloat(f)
+	default:
+		return ErrUnsupportedConversion
+	}
+
+	return nil
+}
+
+// integerBase returns 0, letting strconv derive the base from the prefix, if text has an explicit 0x, 0o or 0b prefix,
+// and 10 otherwise. Leading zeros alone don't select octal, so zero-padded decimals like "007" keep their value.
+func integerBase(text string) int {
+	text = strings.TrimLeft(text, "+-")
+	if len(text) > 2 && text[0] == '0' {
+		switch text[1] {
+		case 'x', 'X', 'o', 'O', 'b', 'B':
+			return 0
+		}
+	}
+	return 10
+}
+
+// limitStringLength enforces the limit set by SetMaxStringLength on targets whose kind is string.
+func limitStringLength[T any](value T) (T, error) {
+	s := loadSettings()
+	if s.maxLength <= 0 {
+		return value, nil
+	}
+
+	rv := reflect.ValueOf(&value).Elem()
+	if rv.Kind() != reflect.String || utf8.RuneCountInString(rv.String()) <= s.maxLength {
+		return value, nil
+	}
+
+	if s.lengthMode != StringLengthTruncate {
+		return zeroValue[T](), fmt.Errorf("%w: more than %d characters", ErrStringTooLong, s.maxLength)
+	}
+
+	text := rv.String()
+	cut, count := 0, 0
+	for cut = range text {
+		if count == s.maxLength {
+			break
+		}
+		count++
+	}
+	rv.SetString(text[:cut])
+	return value, nil
+}
+
+// normalizeTimeLocation converts time.Time values into the location set by SetScanTimeLocation, if any.
+func normalizeTimeLocation[T any](value T) T {
+	loc := loadSettings().scanLocation
+	if loc == nil {
+		return value
+	}
+
+	if t, ok := any(&value).(*time.Time); ok {
+		*t = t.In(loc)
+	}
+	return value
+}
@@ -0,0 +1,963 @@
+package gonull
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNullableScanHardwareAddr(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    string
+		valid   bool
+		wantErr bool
+	}{
+		{name: "string", value: "08:00:2b:01:02:03", want: "08:00:2b:01:02:03", valid: true},
+		{name: "bytes", value: []byte("08-00-2b-01-02-03"), want: "08:00:2b:01:02:03", valid: true},
+		{name: "null", value: nil},
+		{name: "malformed", value: "not-a-mac", wantErr: true},
+		{name: "unsupported", value: int64(1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var n Nullable[net.HardwareAddr]
+			err := n.Scan(tt.value)
+			assert.True(t, n.Present)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.False(t, n.Valid)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.valid, n.Valid)
+			if tt.valid {
+				value, err := n.Value()
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, value)
+			}
+		})
+	}
+}
+
+func TestNullableScanIPNet(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    string
+		valid   bool
+		wantErr bool
+	}{
+		{name: "string", value: "192.168.100.0/24", want: "192.168.100.0/24", valid: true},
+		{name: "bytes", value: []byte("2001:db8::/32"), want: "2001:db8::/32", valid: true},
+		{name: "host bits are masked", value: "10.1.2.3/8", want: "10.0.0.0/8", valid: true},
+		{name: "null", value: nil},
+		{name: "malformed", value: "192.168.100.0/99", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var n Nullable[*net.IPNet]
+			err := n.Scan(tt.value)
+			assert.True(t, n.Present)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.False(t, n.Valid)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.valid, n.Valid)
+			if tt.valid {
+				value, err := n.Value()
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, value)
+			}
+		})
+	}
+}
+
+type jsonItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestNullableScanJSONArray(t *testing.T) {
+	t.Run("populated array", func(t *testing.T) {
+		var n Nullable[[]jsonItem]
+		err := n.Scan([]byte(`[{"id":1,"name":"first"},{"id":2,"name":"second"}]`))
+		assert.NoError(t, err)
+		assert.True(t, n.Valid)
+		assert.Equal(t, []jsonItem{{ID: 1, Name: "first"}, {ID: 2, Name: "second"}}, n.Val)
+	})
+
+	t.Run("empty array", func(t *testing.T) {
+		var n Nullable[[]jsonItem]
+		err := n.Scan([]byte(`[]`))
+		assert.NoError(t, err)
+		assert.True(t, n.Valid)
+		assert.NotNil(t, n.Val)
+		assert.Empty(t, n.Val)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		var n Nullable[[]jsonItem]
+		err := n.Scan(nil)
+		assert.NoError(t, err)
+		assert.False(t, n.Valid)
+		assert.True(t, n.Present)
+	})
+
+	t.Run("object from string", func(t *testing.T) {
+		var n Nullable[jsonItem]
+		err := n.Scan(`{"id":3,"name":"third"}`)
+		assert.NoError(t, err)
+		assert.True(t, n.Valid)
+		assert.Equal(t, jsonItem{ID: 3, Name: "third"}, n.Val)
+	})
+
+	t.Run("malformed document", func(t *testing.T) {
+		var n Nullable[[]jsonItem]
+		err := n.Scan([]byte(`[{"id":"one"}]`))
+		assert.Error(t, err)
+		assert.False(t, n.Valid)
+	})
+
+	t.Run("not a document", func(t *testing.T) {
+		var n Nullable[[]jsonItem]
+		err := n.Scan([]byte(`plain text`))
+		assert.ErrorIs(t, err, ErrUnsupportedConversion)
+	})
+}
+
+func TestNullableScanBigRat(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    *big.Rat
+		wantVal driver.Value
+		wantErr bool
+	}{
+		{name: "fraction", value: "1/3", want: big.NewRat(1, 3), wantVal: "1/3"},
+		{name: "decimal bytes", value: []byte("0.25"), want: big.NewRat(1, 4), wantVal: "1/4"},
+		{name: "integer", value: int64(7), want: big.NewRat(7, 1), wantVal: "7"},
+		{name: "null", value: nil},
+		{name: "malformed", value: "one third", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var n Nullable[*big.Rat]
+			err := n.Scan(tt.value)
+			assert.True(t, n.Present)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.False(t, n.Valid)
+				return
+			}
+
+			assert.NoError(t, err)
+			value, err := n.Value()
+			assert.NoError(t, err)
+			if tt.want == nil {
+				assert.False(t, n.Valid)
+				assert.Nil(t, value)
+				return
+			}
+
+			assert.True(t, n.Valid)
+			assert.Zero(t, tt.want.Cmp(n.Val))
+			assert.Equal(t, tt.wantVal, value)
+		})
+	}
+}
+
+func TestNullableScanJSONRawMessage(t *testing.T) {
+	t.Run("object bytes", func(t *testing.T) {
+		src := []byte(`{"a": [1, 2], "b": null}`)
+
+		var n Nullable[json.RawMessage]
+		err := n.Scan(src)
+		assert.NoError(t, err)
+		assert.True(t, n.Valid)
+		assert.Equal(t, json.RawMessage(`{"a": [1, 2], "b": null}`), n.Val)
+
+		src[0] = 'X'
+		assert.Equal(t, byte('{'), n.Val[0], "scanned bytes must not alias the driver buffer")
+
+		value, err := n.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(`{"a": [1, 2], "b": null}`), value)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		var n Nullable[json.RawMessage]
+		err := n.Scan(nil)
+		assert.NoError(t, err)
+		assert.False(t, n.Valid)
+		assert.True(t, n.Present)
+
+		value, err := n.Value()
+		assert.NoError(t, err)
+		assert.Nil(t, value)
+	})
+}
+
+func TestNullableScanBytea(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		for _, raw := range [][]byte{[]byte(`\x0102ff`), []byte(`\x0g`), []byte(`\001\002`)} {
+			var n Nullable[[]byte]
+			err := n.Scan(raw)
+			assert.NoError(t, err)
+			assert.True(t, n.Valid)
+			assert.Equal(t, raw, n.Val)
+		}
+
+		var n Nullable[[]byte]
+		assert.NoError(t, n.Scan(`\xdeadbeef`))
+		assert.Equal(t, []byte(`\xdeadbeef`), n.Val)
+	})
+
+	SetByteaDecoding(true)
+	defer SetByteaDecoding(false)
+
+	tests := []struct {
+		name    string
+		value   any
+		want    []byte
+		valid   bool
+		wantErr bool
+	}{
+		{name: "escape format", value: []byte(`\001\002\\a`), want: []byte{1, 2, '\\', 'a'}, valid: true},
+		{name: "escape format string", value: `\377\000`, want: []byte{0xff, 0x00}, valid: true},
+		{name: "hex format", value: []byte(`\x0102ff`), want: []byte{1, 2, 0xff}, valid: true},
+		{name: "hex format string", value: `\xdeadbeef`, want: []byte{0xde, 0xad, 0xbe, 0xef}, valid: true},
+		{name: "raw bytes", value: []byte{1, 2, 3}, want: []byte{1, 2, 3}, valid: true},
+		{name: "raw bytes with stray backslash", value: []byte(`a\b`), want: []byte(`a\b`), valid: true},
+		{name: "null", value: nil},
+		{name: "malformed hex", value: []byte(`\x0g`), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var n Nullable[[]byte]
+			err := n.Scan(tt.value)
+			assert.True(t, n.Present)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.False(t, n.Valid)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.valid, n.Valid)
+			assert.Equal(t, tt.want, n.Val)
+		})
+	}
+}
+
+type token []byte
+
+func TestNullableScanNamedBytes(t *testing.T) {
+	t.Run("from bytes", func(t *testing.T) {
+		src := []byte("secret")
+
+		var n Nullable[token]
+		err := n.Scan(src)
+		assert.NoError(t, err)
+		assert.True(t, n.Valid)
+		assert.Equal(t, token("secret"), n.Val)
+
+		src[0] = 'X'
+		assert.Equal(t, token("secret"), n.Val)
+	})
+
+	t.Run("from string", func(t *testing.T) {
+		var n Nullable[token]
+		err := n.Scan("secret")
+		assert.NoError(t, err)
+		assert.True(t, n.Valid)
+		assert.Equal(t, token("secret"), n.Val)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		var n Nullable[token]
+		err := n.Scan(nil)
+		assert.NoError(t, err)
+		assert.False(t, n.Valid)
+		assert.True(t, n.Present)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		var n Nullable[token]
+		err := n.Scan(int64(1))
+		assert.ErrorIs(t, err, ErrUnsupportedConversion)
+	})
+}
+
+func TestNullableScanSQLNullTime(t *testing.T) {
+	now := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	var n Nullable[time.Time]
+	err := n.Scan(sql.NullTime{Time: now, Valid: true})
+	assert.NoError(t, err)
+	assert.True(t, n.Valid)
+	assert.True(t, n.Present)
+	assert.Equal(t, now, n.Val)
+
+	err = n.Scan(sql.NullTime{Time: now, Valid: false})
+	assert.NoError(t, err)
+	assert.False(t, n.Valid)
+	assert.True(t, n.Present)
+	assert.Equal(t, time.Time{}, n.Val)
+}
+
+func TestNullableScanSQLNullTypes(t *testing.T) {
+	t.Run("NullString", func(t *testing.T) {
+		var n Nullable[string]
+		assert.NoError(t, n.Scan(sql.NullString{String: "go", Valid: true}))
+		assert.Equal(t, NewNullable("go"), n)
+		assert.NoError(t, n.Scan(sql.NullString{String: "stale"}))
+		assert.Equal(t, Nullable[string]{Present: true}, n)
+	})
+
+	t.Run("NullInt64", func(t *testing.T) {
+		var n Nullable[int64]
+		assert.NoError(t, n.Scan(sql.NullInt64{Int64: 64, Valid: true}))
+		assert.Equal(t, NewNullable(int64(64)), n)
+		assert.NoError(t, n.Scan(sql.NullInt64{}))
+		assert.Equal(t, Nullable[int64]{Present: true}, n)
+	})
+
+	t.Run("NullInt32", func(t *testing.T) {
+		var n Nullable[int32]
+		assert.NoError(t, n.Scan(sql.NullInt32{Int32: 32, Valid: true}))
+		assert.Equal(t, NewNullable(int32(32)), n)
+		assert.NoError(t, n.Scan(sql.NullInt32{}))
+		assert.Equal(t, Nullable[int32]{Present: true}, n)
+	})
+
+	t.Run("NullInt16", func(t *testing.T) {
+		var n Nullable[int16]
+		assert.NoError(t, n.Scan(sql.NullInt16{Int16: 16, Valid: true}))
+		assert.Equal(t, NewNullable(int16(16)), n)
+		assert.NoError(t, n.Scan(sql.NullInt16{}))
+		assert.Equal(t, Nullable[int16]{Present: true}, n)
+	})
+
+	t.Run("NullByte", func(t *testing.T) {
+		var n Nullable[byte]
+		assert.NoError(t, n.Scan(sql.NullByte{Byte: 8, Valid: true}))
+		assert.Equal(t, NewNullable(byte(8)), n)
+		assert.NoError(t, n.Scan(sql.NullByte{}))
+		assert.Equal(t, Nullable[byte]{Present: true}, n)
+	})
+
+	t.Run("NullFloat64", func(t *testing.T) {
+		var n Nullable[float64]
+		assert.NoError(t, n.Scan(sql.NullFloat64{Float64: 1.5, Valid: true}))
+		assert.Equal(t, NewNullable(1.5), n)
+		assert.NoError(t, n.Scan(sql.NullFloat64{}))
+		assert.Equal(t, Nullable[float64]{Present: true}, n)
+	})
+
+	t.Run("NullBool", func(t *testing.T) {
+		var n Nullable[bool]
+		assert.NoError(t, n.Scan(sql.NullBool{Bool: true, Valid: true}))
+		assert.Equal(t, NewNullable(true), n)
+		assert.NoError(t, n.Scan(sql.NullBool{}))
+		assert.Equal(t, Nullable[bool]{Present: true}, n)
+	})
+
+	t.Run("converts the inner value", func(t *testing.T) {
+		var n Nullable[int32]
+		assert.NoError(t, n.Scan(sql.NullInt64{Int64: 7, Valid: true}))
+		assert.Equal(t, NewNullable(int32(7)), n)
+	})
+}
+
+// jsonMoney is stored as {"amount":"12.50","currency":"EUR"} and parsed by its own UnmarshalJSON.
+type jsonMoney string
+
+func (m *jsonMoney) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Currency == "" {
+		return errors.New("missing currency")
+	}
+	*m = jsonMoney(raw.Amount + " " + raw.Currency)
+	return nil
+}
+
+func TestNullableScanJSONUnmarshaler(t *testing.T) {
+	var n Nullable[jsonMoney]
+	err := n.Scan([]byte(`{"amount":"12.50","currency":"EUR"}`))
+	assert.NoError(t, err)
+	assert.True(t, n.Valid)
+	assert.Equal(t, jsonMoney("12.50 EUR"), n.Val)
+
+	err = n.Scan(`{"amount":"1"}`)
+	assert.EqualError(t, err, "missing currency")
+	assert.False(t, n.Valid)
+
+	err = n.Scan(nil)
+	assert.NoError(t, err)
+	assert.False(t, n.Valid)
+	assert.True(t, n.Present)
+}
+
+func TestNullableScanTinyIntBool(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    bool
+		wantErr bool
+	}{
+		{name: "int64 one", value: int64(1), want: true},
+		{name: "int64 zero", value: int64(0), want: false},
+		{name: "int64 non-zero", value: int64(2), want: true},
+		{name: "ascii one", value: []byte("1"), want: true},
+		{name: "ascii zero", value: []byte("0"), want: false},
+		{name: "string one", value: "1", want: true},
+		{name: "raw byte one", value: []byte{1}, want: true},
+		{name: "raw byte zero", value: []byte{0}, want: false},
+		{name: "invalid text", value: []byte("2"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var n Nullable[bool]
+			err := n.Scan(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.False(t, n.Valid)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, n.Valid)
+			assert.Equal(t, tt.want, n.Val)
+		})
+	}
+
+	type flag bool
+	var n Nullable[flag]
+	assert.NoError(t, n.Scan(int64(1)))
+	assert.Equal(t, flag(true), n.Val)
+}
+
+type driverString struct {
+	raw string
+}
+
+func (d driverString) String() string {
+	return d.raw
+}
+
+func TestNullableScanStringer(t *testing.T) {
+	var n Nullable[string]
+	err := n.Scan(driverString{raw: "wrapped"})
+	assert.NoError(t, err)
+	assert.True(t, n.Valid)
+	assert.Equal(t, "wrapped", n.Val)
+
+	var i Nullable[int]
+	err = i.Scan(driverString{raw: "1"})
+	assert.ErrorIs(t, err, ErrUnsupportedConversion)
+}
+
+type driverInt int64
+
+func TestNullableScanNamedIntDriverValue(t *testing.T) {
+	var n Nullable[int]
+	err := n.Scan(driverInt(42))
+	assert.NoError(t, err)
+	assert.True(t, n.Valid)
+	assert.Equal(t, 42, n.Val)
+
+	var f Nullable[float64]
+	err = f.Scan(driverInt(-3))
+	assert.NoError(t, err)
+	assert.Equal(t, float64(-3), f.Val)
+}
+
+func TestNullableScanJSONNumber(t *testing.T) {
+	var i Nullable[int64]
+	err := i.Scan(json.Number("9007199254740993"))
+	assert.NoError(t, err)
+	assert.True(t, i.Valid)
+	assert.Equal(t, int64(9007199254740993), i.Val)
+
+	var f Nullable[float64]
+	err = f.Scan(json.Number("12.5"))
+	assert.NoError(t, err)
+	assert.True(t, f.Valid)
+	assert.Equal(t, 12.5, f.Val)
+
+	err = i.Scan(json.Number("12.5"))
+	assert.Error(t, err)
+	assert.False(t, i.Valid)
+
+	var small Nullable[int8]
+	err = small.Scan(json.Number("300"))
+	assert.Error(t, err)
+	assert.False(t, small.Valid)
+}
+
+func TestNullableScanBoolIntoNumber(t *testing.T) {
+	var i Nullable[int]
+	assert.NoError(t, i.Scan(true))
+	assert.Equal(t, NewNullable(1), i)
+	assert.NoError(t, i.Scan(false))
+	assert.Equal(t, NewNullable(0), i)
+
+	var f Nullable[float64]
+	assert.NoError(t, f.Scan(true))
+	assert.Equal(t, NewNullable(1.0), f)
+	assert.NoError(t, f.Scan(false))
+	assert.Equal(t, NewNullable(0.0), f)
+}
+
+func TestNullableScanAssignableToInterface(t *testing.T) {
+	var stringer Nullable[fmt.Stringer]
+	err := stringer.Scan(driverString{raw: "boxed"})
+	assert.NoError(t, err)
+	assert.True(t, stringer.Valid)
+	assert.Equal(t, "boxed", stringer.Val.String())
+
+	err = stringer.Scan(int64(1))
+	assert.ErrorIs(t, err, ErrUnsupportedConversion)
+	assert.False(t, stringer.Valid)
+
+	var anything Nullable[any]
+	err = anything.Scan(int64(42))
+	assert.NoError(t, err)
+	assert.True(t, anything.Valid)
+	assert.Equal(t, int64(42), anything.Val)
+
+	ptr := &jsonItem{ID: 1}
+	var pointer Nullable[*jsonItem]
+	err = pointer.Scan(ptr)
+	assert.NoError(t, err)
+	assert.Same(t, ptr, pointer.Val)
+}
+
+func TestNullableScanDecodedJSONMap(t *testing.T) {
+	input := map[string]any{"id": float64(7), "name": "seven"}
+
+	t.Run("struct", func(t *testing.T) {
+		var n Nullable[jsonItem]
+		err := n.Scan(input)
+		assert.NoError(t, err)
+		assert.True(t, n.Valid)
+		assert.Equal(t, jsonItem{ID: 7, Name: "seven"}, n.Val)
+	})
+
+	t.Run("same map type", func(t *testing.T) {
+		var n Nullable[map[string]any]
+		err := n.Scan(input)
+		assert.NoError(t, err)
+		assert.True(t, n.Valid)
+		assert.Equal(t, input, n.Val)
+	})
+
+	t.Run("other map type", func(t *testing.T) {
+		var n Nullable[map[string]string]
+		err := n.Scan(map[string]any{"a": "x", "b": "y"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"a": "x", "b": "y"}, n.Val)
+	})
+
+	t.Run("mismatched field type", func(t *testing.T) {
+		var n Nullable[jsonItem]
+		err := n.Scan(map[string]any{"id": "seven"})
+		assert.Error(t, err)
+		assert.False(t, n.Valid)
+	})
+}
+
+func TestNullableScanNumericText(t *testing.T) {
+	t.Run("integer widths", func(t *testing.T) {
+		var i8 Nullable[int8]
+		assert.NoError(t, i8.Scan([]byte("-128")))
+		assert.Equal(t, int8(-128), i8.Val)
+
+		var i32 Nullable[int32]
+		assert.NoError(t, i32.Scan([]byte("2147483647")))
+		assert.Equal(t, int32(2147483647), i32.Val)
+
+		var u16 Nullable[uint16]
+		assert.NoError(t, u16.Scan("65535"))
+		assert.Equal(t, uint16(65535), u16.Val)
+
+		var u64 Nullable[uint64]
+		assert.NoError(t, u64.Scan([]byte("18446744073709551615")))
+		assert.Equal(t, uint64(18446744073709551615), u64.Val)
+		assert.True(t, u64.Valid)
+	})
+
+	t.Run("floats", func(t *testing.T) {
+		var f32 Nullable[float32]
+		assert.NoError(t, f32.Scan([]byte("12.5")))
+		assert.Equal(t, float32(12.5), f32.Val)
+
+		var f64 Nullable[float64]
+		assert.NoError(t, f64.Scan(" 0.125 "))
+		assert.Equal(t, 0.125, f64.Val)
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		var i32 Nullable[int32]
+		err := i32.Scan([]byte("2147483648"))
+		assert.ErrorIs(t, err, strconv.ErrRange)
+		assert.False(t, i32.Valid)
+
+		var u8 Nullable[uint8]
+		err = u8.Scan([]byte("-1"))
+		assert.Error(t, err)
+		assert.False(t, u8.Valid)
+	})
+
+	t.Run("not a number", func(t *testing.T) {
+		var i Nullable[int]
+		err := i.Scan([]byte("12.50"))
+		assert.ErrorIs(t, err, strconv.ErrSyntax)
+		assert.False(t, i.Valid)
+	})
+}
+
+func TestNullableScanBoolWords(t *testing.T) {
+	tests := []struct {
+		value any
+		want  bool
+	}{
+		{value: []byte("true"), want: true},
+		{value: []byte("false"), want: false},
+		{value: []byte("t"), want: true},
+		{value: []byte("f"), want: false},
+		{value: "TRUE", want: true},
+	}
+
+	for _, tt := range tests {
+		var n Nullable[bool]
+		err := n.Scan(tt.value)
+		assert.NoError(t, err)
+		assert.True(t, n.Valid)
+		assert.Equal(t, tt.want, n.Val)
+	}
+
+	var n Nullable[bool]
+	err := n.Scan(nil)
+	assert.NoError(t, err)
+	assert.False(t, n.Valid)
+	assert.True(t, n.Present)
+
+	err = n.Scan([]byte("yes"))
+	assert.Error(t, err)
+	assert.False(t, n.Valid)
+}
+
+// pbStatus mimics a protobuf-generated enum, which is an int32-based named type.
+type pbStatus int32
+
+const (
+	pbStatusUnknown pbStatus = 0
+	pbStatusActive  pbStatus = 1
+)
+
+func TestNullableScanInt32Enum(t *testing.T) {
+	var n Nullable[pbStatus]
+	err := n.Scan(int64(1))
+	assert.NoError(t, err)
+	assert.True(t, n.Valid)
+	assert.Equal(t, pbStatusActive, n.Val)
+
+	err = n.Scan(int64(0))
+	assert.NoError(t, err)
+	assert.Equal(t, pbStatusUnknown, n.Val)
+
+	err = n.Scan(int64(1) << 40)
+	assert.ErrorIs(t, err, strconv.ErrRange)
+	assert.False(t, n.Valid)
+}
+
+func TestNullableScanNumericRange(t *testing.T) {
+	var u Nullable[uint32]
+	assert.ErrorIs(t, u.Scan(int64(-1)), strconv.ErrRange)
+
+	var i8 Nullable[int8]
+	assert.ErrorIs(t, i8.Scan(int64(128)), strconv.ErrRange)
+	assert.NoError(t, i8.Scan(int64(-128)))
+
+	var f32 Nullable[float32]
+	assert.ErrorIs(t, f32.Scan(float64(1e39)), strconv.ErrRange)
+
+	var i Nullable[int64]
+	assert.ErrorIs(t, i.Scan(uint64(1)<<63), strconv.ErrRange)
+	assert.ErrorIs(t, i.Scan(float64(1e19)), strconv.ErrRange)
+	assert.NoError(t, i.Scan(float64(12)))
+	assert.Equal(t, int64(12), i.Val)
+
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		assert.ErrorIs(t, i.Scan(f), strconv.ErrRange)
+		assert.False(t, i.Valid)
+		assert.ErrorIs(t, u.Scan(f), strconv.ErrRange)
+	}
+	assert.NoError(t, f32.Scan(math.Inf(1)))
+	assert.True(t, math.IsInf(float64(f32.Val), 1))
+}
+
+// arrayStatus is a named string, as used for PostgreSQL enum types.
+type arrayStatus string
+
+func TestNullableScanPostgresArray(t *testing.T) {
+	t.Run("named string elements", func(t *testing.T) {
+		var n Nullable[[]arrayStatus]
+		err := n.Scan([]byte("{active,inactive}"))
+		assert.NoError(t, err)
+		assert.True(t, n.Valid)
+		assert.Equal(t, []arrayStatus{"active", "inactive"}, n.Val)
+	})
+
+	t.Run("quoted elements and NULL", func(t *testing.T) {
+		var n Nullable[[]string]
+		err := n.Scan(`{"a,b","say \"hi\"",NULL,"NULL", plain }`)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a,b", `say "hi"`, "", "NULL", "plain"}, n.Val)
+	})
+
+	t.Run("numeric and bool elements", func(t *testing.T) {
+		var ints Nullable[[]int32]
+		assert.NoError(t, ints.Scan("{1,-2,3}"))
+		assert.Equal(t, []int32{1, -2, 3}, ints.Val)
+
+		var flags Nullable[[]bool]
+		assert.NoError(t, flags.Scan("{t,f}"))
+		assert.Equal(t, []bool{true, false}, flags.Val)
+
+		assert.Error(t, ints.Scan("{1,x}"))
+		assert.False(t, ints.Valid)
+	})
+
+	t.Run("nullable elements", func(t *testing.T) {
+		var n Nullable[[]Nullable[int]]
+		assert.NoError(t, n.Scan("{1,NULL}"))
+		assert.Equal(t, []Nullable[int]{NewNullable(1), {Present: true}}, n.Val)
+	})
+
+	t.Run("well-known and Parser elements", func(t *testing.T) {
+		var times Nullable[[]time.Time]
+		assert.NoError(t, times.Scan(`{"2024-05-01 12:30:00",NULL}`))
+		assert.Len(t, times.Val, 2)
+		assert.True(t, times.Val[0].Equal(time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)))
+		assert.True(t, times.Val[1].IsZero())
+
+		var ips Nullable[[]net.IP]
+		assert.NoError(t, ips.Scan("{192.168.0.1,::1}"))
+		assert.Equal(t, []net.IP{net.ParseIP("192.168.0.1"), net.ParseIP("::1")}, ips.Val)
+
+		var versions Nullable[[]semver]
+		assert.NoError(t, versions.Scan("{v1.2.3,v2.0.0}"))
+		assert.Equal(t, []semver{{Major: 1, Minor: 2, Patch: 3}, {Major: 2}}, versions.Val)
+		assert.Error(t, versions.Scan("{latest}"))
+	})
+
+	t.Run("empty array", func(t *testing.T) {
+		var n Nullable[[]arrayStatus]
+		assert.NoError(t, n.Scan("{}"))
+		assert.True(t, n.Valid)
+		assert.Equal(t, []arrayStatus{}, n.Val)
+	})
+
+	t.Run("JSON arrays still work", func(t *testing.T) {
+		var n Nullable[[]arrayStatus]
+		assert.NoError(t, n.Scan(`["active"]`))
+		assert.Equal(t, []arrayStatus{"active"}, n.Val)
+	})
+
+	t.Run("multidimensional arrays are rejected", func(t *testing.T) {
+		var n Nullable[[]string]
+		assert.Error(t, n.Scan("{{a},{b}}"))
+	})
+}
+
+// grade is a float-based enum, stored as a floating-point column.
+type grade float32
+
+const (
+	gradeFail grade = 0
+	gradePass grade = 2.5
+)
+
+func TestNullableScanNamedFloatEnum(t *testing.T) {
+	var n Nullable[grade]
+	err := n.Scan(float64(2.5))
+	assert.NoError(t, err)
+	assert.True(t, n.Valid)
+	assert.Equal(t, gradePass, n.Val)
+
+	err = n.Scan(int64(0))
+	assert.NoError(t, err)
+	assert.Equal(t, gradeFail, n.Val)
+
+	err = n.Scan("2.5")
+	assert.NoError(t, err)
+	assert.Equal(t, gradePass, n.Val)
+
+	err = n.Scan(float64(1e39))
+	assert.ErrorIs(t, err, strconv.ErrRange)
+	assert.False(t, n.Valid)
+}
+
+func TestNullableScanPointerInputs(t *testing.T) {
+	i := int64(42)
+	var n Nullable[int64]
+	assert.NoError(t, n.Scan(&i))
+	assert.Equal(t, NewNullable(int64(42)), n)
+
+	assert.NoError(t, n.Scan((*int64)(nil)))
+	assert.Equal(t, Nullable[int64]{Present: true}, n)
+
+	var narrow Nullable[int16]
+	assert.NoError(t, narrow.Scan(&i))
+	assert.Equal(t, int16(42), narrow.Val)
+
+	s := "hello"
+	var str Nullable[string]
+	assert.NoError(t, str.Scan(&s))
+	assert.Equal(t, NewNullable("hello"), str)
+
+	assert.NoError(t, str.Scan((*string)(nil)))
+	assert.True(t, str.Present)
+	assert.False(t, str.Valid)
+
+	// *big.Int implements fmt.Stringer with a pointer receiver only.
+	assert.NoError(t, str.Scan(big.NewInt(7)))
+	assert.Equal(t, "7", str.Val)
+
+	var b Nullable[bool]
+	assert.Error(t, b.Scan(&s))
+	assert.False(t, b.Valid)
+}
+
+func TestNullableScanPrefixedIntegerText(t *testing.T) {
+	var n Nullable[int]
+	assert.NoError(t, n.Scan([]byte("0x1F")))
+	assert.True(t, n.Valid)
+	assert.Equal(t, 31, n.Val)
+
+	assert.NoError(t, n.Scan("0b101"))
+	assert.Equal(t, 5, n.Val)
+
+	assert.NoError(t, n.Scan([]byte("-0X10")))
+	assert.Equal(t, -16, n.Val)
+
+	assert.NoError(t, n.Scan([]byte("007")))
+	assert.Equal(t, 7, n.Val)
+
+	err := n.Scan([]byte("0x1G"))
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.False(t, n.Valid)
+
+	var flags Nullable[uint8]
+	assert.NoError(t, flags.Scan([]byte("0xff")))
+	assert.Equal(t, uint8(255), flags.Val)
+	assert.ErrorIs(t, flags.Scan([]byte("0x100")), strconv.ErrRange)
+}
+
+func TestNullableScanAnySlice(t *testing.T) {
+	var n Nullable[[]int]
+	err := n.Scan([]any{1, 2, 3})
+	assert.NoError(t, err)
+	assert.True(t, n.Valid)
+	assert.Equal(t, []int{1, 2, 3}, n.Val)
+
+	err = n.Scan([]any{int64(4), "5", nil})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 5, 0}, n.Val)
+
+	err = n.Scan([]any{1, true})
+	assert.ErrorIs(t, err, ErrUnsupportedConversion)
+	assert.EqualError(t, err, "element 1: unsupported type conversion")
+	assert.False(t, n.Valid)
+
+	var small Nullable[[]int8]
+	assert.ErrorIs(t, small.Scan([]any{int64(1) << 40}), strconv.ErrRange)
+
+	assert.NoError(t, n.Scan(nil))
+	assert.True(t, n.Present)
+	assert.False(t, n.Valid)
+
+	var statuses Nullable[[]arrayStatus]
+	assert.NoError(t, statuses.Scan([]any{"active", []byte("inactive")}))
+	assert.Equal(t, []arrayStatus{"active", "inactive"}, statuses.Val)
+
+	var matrix Nullable[[][]float64]
+	assert.NoError(t, matrix.Scan([]any{[]any{1, 2.5}, []any{}}))
+	assert.Equal(t, [][]float64{{1, 2.5}, {}}, matrix.Val)
+
+	var nullable Nullable[[]Nullable[string]]
+	assert.NoError(t, nullable.Scan([]any{"a", nil}))
+	assert.Equal(t, []Nullable[string]{NewNullable("a"), {Present: true}}, nullable.Val)
+
+	deadline := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	var times Nullable[[]time.Time]
+	assert.NoError(t, times.Scan([]any{deadline, "2024-05-01T12:30:00Z"}))
+	assert.Equal(t, []time.Time{deadline, deadline}, times.Val)
+
+	var ips Nullable[[]net.IP]
+	assert.NoError(t, ips.Scan([]any{[]byte("10.0.0.1")}))
+	assert.Equal(t, []net.IP{net.ParseIP("10.0.0.1")}, ips.Val)
+
+	var versions Nullable[[]semver]
+	assert.NoError(t, versions.Scan([]any{"v1.2.3"}))
+	assert.Equal(t, []semver{{Major: 1, Minor: 2, Patch: 3}}, versions.Val)
+}
+
+// semver only implements Parser, not sql.Scanner.
+type semver struct {
+	Major, Minor, Patch int
+}
+
+func (v *semver) Parse(text string) error {
+	_, err := fmt.Sscanf(text, "v%d.%d.%d", &v.Major, &v.Minor, &v.Patch)
+	return err
+}
+
+func TestNullableScanParser(t *testing.T) {
+	var n Nullable[semver]
+	err := n.Scan("v1.22.3")
+	assert.NoError(t, err)
+	assert.True(t, n.Valid)
+	assert.Equal(t, semver{Major: 1, Minor: 22, Patch: 3}, n.Val)
+
+	err = n.Scan([]byte("v2.0.1"))
+	assert.NoError(t, err)
+	assert.Equal(t, semver{Major: 2, Minor: 0, Patch: 1}, n.Val)
+
+	err = n.Scan("latest")
+	assert.Error(t, err)
+	assert.False(t, n.Valid)
+	assert.Equal(t, semver{}, n.Val)
+
+	err = n.Scan(int64(1))
+	assert.ErrorIs(t, err, ErrUnsupportedConversion)
+}
@@ -0,0 +1,36 @@
+package examples
+
+import (
+	"fmt"
+
+	"github.com/LukaGiorgadze/gonull"
+)
+
+// Point is stored in the database as "lat,lng" text.
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+func Example_registerConverter() {
+	gonull.RegisterConverter(func(value any) (Point, error) {
+		text, ok := value.(string)
+		if !ok {
+			return Point{}, gonull.ErrUnsupportedConversion
+		}
+		var p Point
+		_, err := fmt.Sscanf(text, "%g,%g", &p.Lat, &p.Lng)
+		return p, err
+	})
+	defer gonull.RegisterConverter[Point](nil)
+
+	// The driver returns the column as a string.
+	var location gonull.Nullable[Point]
+	if err := location.Scan("41.7151,44.8271"); err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Valid: %t, Lat: %g, Lng: %g\n", location.Valid, location.Val.Lat, location.Val.Lng)
+	// Output:
+	// Valid: true, Lat: 41.7151, Lng: 44.8271
+}
@@ -0,0 +1,58 @@
+package gonull
+
+// ReadOnlyNullable is the accessor-only view of a nullable value. Accepting it instead of a Nullable
+// communicates at API boundaries that the value is not going to be modified.
+type ReadOnlyNullable[T any] interface {
+	// Get returns the value and true if it is valid, and the zero value of T and false otherwise.
+	Get() (T, bool)
+	// OrElse returns the value if it is valid and defaultVal otherwise.
+	OrElse(defaultVal T) T
+	// IsValid reports whether the value is valid, i.e. not null.
+	IsValid() bool
+	// IsPresent reports whether the value was present, including present nulls.
+	IsPresent() bool
+}
+
+// FrozenNullable is an immutable snapshot of a Nullable created by Freeze. Its state is unexported,
+// so it can only be read through its accessor methods.
+// Since T is copied as is, values of reference types such as slices and maps still share their contents with the original.
+type FrozenNullable[T any] struct {
+	n Nullable[T]
+}
+
+var _ ReadOnlyNullable[int] = FrozenNullable[int]{}
+
+// Freeze returns an immutable view of the Nullable. Later changes to the Nullable are not reflected in the view.
+func (n Nullable[T]) Freeze() FrozenNullable[T] {
+	return FrozenNullable[T]{n: n}
+}
+
+// Get returns the value and true if it is valid, and the zero value of T and false otherwise.
+func (f FrozenNullable[T]) Get() (T, bool) {
+	return f.n.Get()
+}
+
+// OrElse returns the value if it is valid and defaultVal otherwise.
+func (f FrozenNullable[T]) OrElse(defaultVal T) T {
+	return f.n.OrElse(defaultVal)
+}
+
+// IsValid reports whether the value is valid, i.e. not null.
+func (f FrozenNullable[T]) IsValid() bool {
+	return f.n.Valid
+}
+
+// IsPresent reports whether the value was present, including present nulls.
+func (f FrozenNullable[T]) IsPresent() bool {
+	return f.n.Present
+}
+
+// Thaw returns a mutable copy of the frozen Nullable.
+func (f FrozenNullable[T]) Thaw() Nullable[T] {
+	return f.n
+}
+
+// MarshalJSON encodes the frozen value the same way as the Nullable it was created from.
+func (f FrozenNullable[T]) MarshalJSON() ([]byte, error) {
+	return f.n.MarshalJSON()
+}
@@ -0,0 +1,45 @@
+package gonull
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNullableFreeze(t *testing.T) {
+	n := NewNullable("original")
+	var view ReadOnlyNullable[string] = n.Freeze()
+
+	value, ok := view.Get()
+	assert.True(t, ok)
+	assert.Equal(t, "original", value)
+	assert.Equal(t, "original", view.OrElse("default"))
+	assert.True(t, view.IsValid())
+	assert.True(t, view.IsPresent())
+
+	n.Val = "changed"
+	n.Valid = false
+	value, ok = view.Get()
+	assert.True(t, ok)
+	assert.Equal(t, "original", value)
+
+	null := Nullable[string]{Val: "stale", Present: true}.Freeze()
+	value, ok = null.Get()
+	assert.False(t, ok)
+	assert.Equal(t, "", value)
+	assert.Equal(t, "default", null.OrElse("default"))
+	assert.False(t, null.IsValid())
+	assert.True(t, null.IsPresent())
+
+	absent := Nullable[int]{}.Freeze()
+	assert.False(t, absent.IsValid())
+	assert.False(t, absent.IsPresent())
+
+	thawed := n.Freeze().Thaw()
+	assert.Equal(t, n, thawed)
+
+	data, err := json.Marshal(NewNullable(5).Freeze())
+	assert.NoError(t, err)
+	assert.Equal(t, "5", string(data))
+}
@@ -3,11 +3,19 @@
 package gonull
 
 import (
+	"context"
 	"database/sql"
 	"database/sql/driver"
+	"encoding"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"math/big"
+	"net"
 	"reflect"
 	"time"
 )
@@ -16,8 +24,26 @@ var (
 	// ErrUnsupportedConversion is an error that occurs when attempting to convert a value to an unsupported type.
 	// This typically happens when Scan is called with a value that cannot be converted to the target type T.
 	ErrUnsupportedConversion = errors.New("unsupported type conversion")
+
+	// ErrAbsent is returned by Require when the value was not present, e.g. the field was missing from the JSON input.
+	ErrAbsent = errors.New("required value is absent")
+
+	// ErrNull is returned by Require when the value was present but explicitly set to null.
+	ErrNull = errors.New("required value is null")
+
+	// ErrInconsistentState is returned by Ensure when the flags of a Nullable describe an impossible state.
+	ErrInconsistentState = errors.New("inconsistent Nullable state")
 )
 
+// NullHash is the hash returned by Hash for every invalid Nullable.
+const NullHash uint64 = 0
+
+// Parser is implemented by types that can parse themselves from text, as many libraries' types do.
+// Scan uses it for string and []byte driver values, so such types don't need to implement sql.Scanner.
+type Parser interface {
+	Parse(text string) error
+}
+
 // Nullable is a generic struct that holds a nullable value of any type T.
 // It keeps track of the value (Val), a flag (Valid) indicating whether the value has been set and a flag (Present)
 // indicating if the value is in the struct.
@@ -26,6 +52,9 @@ type Nullable[T any] struct {
 	Val     T
 	Valid   bool
 	Present bool
+
+	// scannedType is the type of the last driver value passed to Scan, recorded when SetRecordScannedType is enabled.
+	scannedType reflect.Type
 }
 
 // NewNullable creates a new Nullable with the given value and sets Valid to true.
@@ -34,13 +63,70 @@ func NewNullable[T any](value T) Nullable[T] {
 	return Nullable[T]{Val: value, Valid: true, Present: true}
 }
 
+// FromPtr creates a Nullable from a pointer, as used by protobuf optionals and older structs.
+// A nil pointer yields a present but invalid Nullable, since the field it comes from exists,
+// and any other pointer yields a valid Nullable holding a copy of the value it points to. It is the inverse of Ptr.
+func FromPtr[T any](p *T) Nullable[T] {
+	if p == nil {
+		return Nullable[T]{Present: true}
+	}
+	return NewNullable(*p)
+}
+
+// WithPresent returns a copy of the Nullable with the Present flag set to p.
+// Together with WithValid it allows building any state fluently, e.g. for test fixtures.
+func (n Nullable[T]) WithPresent(p bool) Nullable[T] {
+	n.Present = p
+	return n
+}
+
+// WithValid returns a copy of the Nullable with the Valid flag set to v.
+// For example, NewNullable(x).WithValid(false) is a present null that still carries x in Val.
+func (n Nullable[T]) WithValid(v bool) Nullable[T] {
+	n.Valid = v
+	return n
+}
+
+// Set stores value and marks the Nullable as valid and present.
+func (n *Nullable[T]) Set(value T) {
+	n.Val = value
+	n.Valid = true
+	n.Present = true
+}
+
+// Clear makes the Nullable a present null, e.g. a field explicitly set to null in JSON. Val is reset to the zero value.
+func (n *Nullable[T]) Clear() {
+	n.Val = zeroValue[T]()
+	n.Valid = false
+	n.Present = true
+}
+
+// Unset makes the Nullable absent, as if the field was missing from the input. Val is reset to the zero value.
+func (n *Nullable[T]) Unset() {
+	n.Val = zeroValue[T]()
+	n.Valid = false
+	n.Present = false
+}
+
 // Scan implements the sql.Scanner interface for Nullable, allowing it to be used as a nullable field in database operations.
 // It is responsible for properly setting the Valid flag and converting the scanned value to the target type T.
 // This enables seamless integration with database/sql when working with nullable values.
 func (n *Nullable[T]) Scan(value any) error {
 	n.Present = true
+	if loadSettings().recordScannedType {
+		n.scannedType = reflect.TypeOf(value)
+	}
 
-	if value == nil {
+	value, err := unwrapSQLNull(value)
+	if err != nil {
+		n.Val = zeroValue[T]()
+		n.Valid = false
+		return err
+	}
+
+	// Some drivers return pointers for nullable columns, where a nil pointer stands for NULL,
+	// or empty strings for NULL timestamps, which are accepted when SetEmptyTimeAsNull is enabled.
+	if value == nil || isNilPointer(value) || isEmptyTimeText[T](value) {
 		n.Val = zeroValue[T]()
 		n.Valid = false
 		return nil
@@ -50,16 +136,31 @@ func (n *Nullable[T]) Scan(value any) error {
 		if err := scanner.Scan(value); err != nil {
 			return err
 		}
+		n.Val = normalize(n.Val)
 		n.Valid = true
 		return nil
 	}
 
-	var err error
 	n.Val, err = convertToType[T](value)
+	if err == nil {
+		n.Val, err = limitStringLength(n.Val)
+	}
+	if err == nil {
+		n.Val = normalize(normalizeTimeLocation(n.Val))
+	}
 	n.Valid = err == nil
+	if onScanError := loadSettings().onScanError; onScanError != nil && errors.Is(err, ErrUnsupportedConversion) {
+		return onScanError(reflect.TypeOf((*T)(nil)).Elem(), value)
+	}
 	return err
 }
 
+// ScannedType returns the type of the driver value last passed to Scan, which helps diagnose why a conversion
+// produced an unexpected result. It is nil before any scan, after scanning NULL and unless SetRecordScannedType is enabled.
+func (n Nullable[T]) ScannedType() reflect.Type {
+	return n.scannedType
+}
+
 // Value implements the driver.Valuer interface for Nullable, enabling it to be used as a nullable field in database operations.
 // This method ensures that the correct value is returned for serialization, handling unset Nullable values by returning nil.
 func (n Nullable[T]) Value() (driver.Value, error) {
@@ -67,18 +168,77 @@ func (n Nullable[T]) Value() (driver.Value, error) {
 		return nil, nil
 	}
 
+	if converter := loadSettings().converter; converter != nil {
+		converted, err := converter.ConvertValue(n.Val)
+		if err != nil {
+			return nil, err
+		}
+		return convertToDriverValue(converted)
+	}
+
 	if valuer, ok := interface{}(n.Val).(driver.Valuer); ok {
 		return valuer.Value()
 	}
 
+	// Types implementing driver.Valuer with a pointer receiver, symmetrically to sql.Scanner in Scan.
+	if valuer, ok := interface{}(&n.Val).(driver.Valuer); ok {
+		return valuer.Value()
+	}
+
 	return convertToDriverValue(n.Val)
 }
 
+// ValueContext is like Value but returns ctx.Err() without converting the value if ctx is already done,
+// so expensive conversions can be skipped once a request times out. If T implements
+// ValueContext(context.Context) (driver.Value, error) itself, the context is passed on to it,
+// unless a converter set with SetValueConverter takes precedence, as it does in Value.
+func (n Nullable[T]) ValueContext(ctx context.Context) (driver.Value, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if !n.Valid {
+		return nil, nil
+	}
+	if loadSettings().converter != nil {
+		return n.Value()
+	}
+
+	if valuer, ok := interface{}(n.Val).(contextValuer); ok {
+		return valuer.ValueContext(ctx)
+	}
+	if valuer, ok := interface{}(&n.Val).(contextValuer); ok {
+		return valuer.ValueContext(ctx)
+	}
+
+	return n.Value()
+}
+
+// contextValuer is implemented by types whose driver value conversion can be cancelled.
+type contextValuer interface {
+	ValueContext(ctx context.Context) (driver.Value, error)
+}
+
 func convertToDriverValue(v any) (driver.Value, error) {
 	if valuer, ok := v.(driver.Valuer); ok {
 		return valuer.Value()
 	}
 
+	switch t := v.(type) {
+	case net.HardwareAddr:
+		return t.String(), nil
+	case *net.IPNet:
+		if t == nil {
+			return nil, nil
+		}
+		return t.String(), nil
+	case *big.Rat:
+		if t == nil {
+			return nil, nil
+		}
+		return t.RatString(), nil
+	}
+
 	rv := reflect.ValueOf(v)
 	switch rv.Kind() {
 	case reflect.Pointer:
@@ -104,6 +264,9 @@ func convertToDriverValue(v any) (driver.Value, error) {
 		return rv.Float(), nil
 
 	case reflect.Bool:
+		if format := loadSettings().boolText; format != nil {
+			return format.text(rv.Bool()), nil
+		}
 		return rv.Bool(), nil
 
 	case reflect.Slice:
@@ -141,7 +304,7 @@ func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	n.Val = value
+	n.Val = normalize(value)
 	n.Valid = true
 	return nil
 }
@@ -153,7 +316,147 @@ func (n Nullable[T]) MarshalJSON() ([]byte, error) {
 		return []byte("null"), nil
 	}
 
-	return json.Marshal(n.Val)
+	if marshal, ok := lookup[T, func(T) ([]byte, error)](&marshalers); ok {
+		return marshal(n.Val)
+	}
+
+	// Val is encoded through a pointer, so marshalers of T declared with a pointer receiver are used as well.
+	// The receiver is a copy, so they can't modify the Nullable.
+	return json.Marshal(&n.Val)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for Nullable, which is used by text-based formats
+// such as environment variable and CSV libraries. Invalid values are encoded as empty text. Valid values are encoded
+// with the encoding.TextMarshaler of T if it has one, and with fmt.Sprint otherwise.
+func (n Nullable[T]) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+
+	if marshaler, ok := interface{}(n.Val).(encoding.TextMarshaler); ok {
+		return marshaler.MarshalText()
+	}
+	if marshaler, ok := interface{}(&n.Val).(encoding.TextMarshaler); ok {
+		return marshaler.MarshalText()
+	}
+
+	return []byte(fmt.Sprint(n.Val)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for Nullable. It always marks the Nullable as present.
+// Empty text is decoded as a null value. Other text is decoded with the encoding.TextUnmarshaler of T if it has one,
+// and otherwise converted like a textual driver value in Scan.
+func (n *Nullable[T]) UnmarshalText(text []byte) error {
+	n.Present = true
+
+	if len(text) == 0 {
+		n.Val = zeroValue[T]()
+		n.Valid = false
+		return nil
+	}
+
+	value := zeroValue[T]()
+	var err error
+	if unmarshaler, ok := interface{}(&value).(encoding.TextUnmarshaler); ok {
+		err = unmarshaler.UnmarshalText(text)
+	} else if value, err = convertToType[T](string(text)); errors.Is(err, ErrUnsupportedConversion) {
+		// Named string types can't be converted from a driver value, but they can hold any text.
+		if rv := reflect.ValueOf(&value).Elem(); rv.Kind() == reflect.String {
+			rv.SetString(string(text))
+			err = nil
+		}
+	}
+	if err != nil {
+		n.Val = zeroValue[T]()
+		n.Valid = false
+		return err
+	}
+
+	n.Val = normalize(value)
+	n.Valid = true
+	return nil
+}
+
+// MarshalJSONWith writes the JSON encoding of the Nullable to enc, so valid values are encoded with the encoder's
+// configuration, e.g. with HTML escaping disabled by SetEscapeHTML(false), without changing global settings.
+// Invalid values are written as null. Like json.Encoder.Encode, it terminates the output with a newline.
+func (n Nullable[T]) MarshalJSONWith(enc *json.Encoder) error {
+	if !n.Valid {
+		return enc.Encode(nil)
+	}
+
+	if marshal, ok := lookup[T, func(T) ([]byte, error)](&marshalers); ok {
+		data, err := marshal(n.Val)
+		if err != nil {
+			return err
+		}
+		return enc.Encode(json.RawMessage(data))
+	}
+
+	return enc.Encode(&n.Val)
+}
+
+// JSONString returns the JSON encoding of the Nullable as a string, or the error text if it can't be marshaled.
+// It is a convenience for debugging and logging.
+func (n Nullable[T]) JSONString() string {
+	data, err := n.MarshalJSON()
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+// PatchOp returns the JSON Patch (RFC 6902) operation for this field at path and whether an operation should be emitted.
+// A valid value yields a replace operation, a present null yields a remove operation, or a replace with a null value
+// when SetPatchNullMode is PatchNullReplace, and an absent value yields no operation.
+func (n Nullable[T]) PatchOp(path string) (map[string]any, bool) {
+	if !n.Present {
+		return nil, false
+	}
+
+	if n.Valid {
+		return map[string]any{"op": "replace", "path": path, "value": n.Val}, true
+	}
+
+	if loadSettings().patchNullMode == PatchNullReplace {
+		return map[string]any{"op": "replace", "path": path, "value": nil}, true
+	}
+	return map[string]any{"op": "remove", "path": path}, true
+}
+
+// SchemaType returns the element type T and reports that the value is nullable.
+// Schema generators, e.g. for OpenAPI, can use it to describe a Nullable field as a nullable T
+// without special-casing the layout of the struct.
+func (n Nullable[T]) SchemaType() (inner reflect.Type, nullable bool) {
+	return reflect.TypeOf((*T)(nil)).Elem(), true
+}
+
+// Get returns Val and true if the Nullable is valid, and the zero value of T and false otherwise,
+// mirroring the comma-ok idiom of map reads and type assertions: if v, ok := n.Get(); ok { ... }.
+func (n Nullable[T]) Get() (T, bool) {
+	if !n.Valid {
+		return zeroValue[T](), false
+	}
+	return n.Val, true
+}
+
+// MustGet returns Val if the Nullable is valid and panics otherwise. It is meant for test fixtures and code paths
+// where a null value is a programmer error, like regexp.MustCompile.
+func (n Nullable[T]) MustGet() T {
+	if !n.Valid {
+		panic(fmt.Sprintf("gonull: MustGet called on invalid Nullable[%s]", reflect.TypeOf((*T)(nil)).Elem()))
+	}
+	return n.Val
+}
+
+// Ptr returns a pointer to a copy of Val if the Nullable is valid and nil otherwise, for interop with code
+// using pointers to represent optional values. Unlike ValuePtr, writes through the pointer don't affect the Nullable.
+func (n Nullable[T]) Ptr() *T {
+	if !n.Valid {
+		return nil
+	}
+	v := n.Val
+	return &v
 }
 
 // OrElse returns the underlying Val if valid otherwise returns the provided defaultVal
@@ -165,6 +468,222 @@ func (n Nullable[T]) OrElse(defaultVal T) T {
 	}
 }
 
+// OrZero returns Val if the Nullable is valid and the zero value of T otherwise,
+// never the stale data an invalid Nullable may still hold in Val.
+func (n Nullable[T]) OrZero() T {
+	if !n.Valid {
+		return zeroValue[T]()
+	}
+	return n.Val
+}
+
+// Display returns Val if the Nullable is valid and nullText otherwise.
+// It is meant for templates, e.g. {{ .Field.Display "N/A" }}, where branching on Valid is cumbersome.
+func (n Nullable[T]) Display(nullText string) any {
+	if n.Valid {
+		return n.Val
+	}
+	return nullText
+}
+
+// Require returns Val if the Nullable is present and valid. It returns ErrAbsent if the value was not present
+// and ErrNull if it was present but null, which is useful for fields that are optional in transport but
+// required by business logic.
+func (n Nullable[T]) Require() (T, error) {
+	if n.Valid {
+		return n.Val, nil
+	}
+	if !n.Present {
+		return zeroValue[T](), ErrAbsent
+	}
+	return zeroValue[T](), ErrNull
+}
+
+// Ensure checks that the flags of the Nullable are consistent, i.e. that a valid Nullable is also present.
+// Nullables built by this package always are, but manually constructed ones may not be.
+// It returns an error wrapping ErrInconsistentState that describes the problem, or nil.
+func (n Nullable[T]) Ensure() error {
+	if n.Valid && !n.Present {
+		return fmt.Errorf("%w: Nullable[%s] is valid but not present", ErrInconsistentState, reflect.TypeOf((*T)(nil)).Elem())
+	}
+	return nil
+}
+
+// OrValue returns the receiver if valid, otherwise a valid Nullable wrapping v.
+// Unlike OrElse, the result stays a Nullable, so it can be chained further.
+func (n Nullable[T]) OrValue(v T) Nullable[T] {
+	if n.Valid {
+		return n
+	}
+	return NewNullable(v)
+}
+
+// MapErr applies f to Val if the Nullable is valid. If f returns an error, the error is discarded and
+// a present but invalid Nullable is returned, which suits best-effort normalization. Invalid values pass through unchanged.
+func (n Nullable[T]) MapErr(f func(T) (T, error)) Nullable[T] {
+	if !n.Valid {
+		return n
+	}
+
+	value, err := f(n.Val)
+	if err != nil {
+		return Nullable[T]{Present: true}
+	}
+	return NewNullable(value)
+}
+
+// Tap calls onValid with Val if the Nullable is valid, or onInvalid otherwise, and returns the receiver unchanged.
+// Either callback may be nil. It allows logging or metrics in the middle of a fluent chain.
+func (n Nullable[T]) Tap(onValid func(T), onInvalid func()) Nullable[T] {
+	if n.Valid {
+		if onValid != nil {
+			onValid(n.Val)
+		}
+	} else if onInvalid != nil {
+		onInvalid()
+	}
+	return n
+}
+
+// ZeroToNull returns a present but invalid Nullable if the receiver is valid and Val is the zero value of T,
+// and the receiver unchanged otherwise. This implements "empty means null" semantics, e.g. for empty strings.
+func (n Nullable[T]) ZeroToNull() Nullable[T] {
+	if n.Valid && reflect.ValueOf(&n.Val).Elem().IsZero() {
+		return Nullable[T]{Present: true}
+	}
+	return n
+}
+
+// CompareTo compares the receiver with other using cmp, following SQL's three-valued logic.
+// If either side is null the comparison is UNKNOWN and the returned bool is false.
+// Otherwise it returns the result of cmp(n.Val, other.Val) and true.
+func (n Nullable[T]) CompareTo(other Nullable[T], cmp func(a, b T) int) (int, bool) {
+	if !n.Valid || !other.Valid {
+		return 0, false
+	}
+	return cmp(n.Val, other.Val), true
+}
+
+// GetOrInsert returns Val if the Nullable is valid. Otherwise it computes the value with f, stores it
+// marking the Nullable as valid and present, and returns it. Subsequent calls return the stored value without calling f.
+func (n *Nullable[T]) GetOrInsert(f func() T) T {
+	if !n.Valid {
+		n.Val = f()
+		n.Valid = true
+		n.Present = true
+	}
+
+	return n.Val
+}
+
+// Accumulate folds f into the Nullable. If the Nullable is not valid, f is applied to the zero value of T,
+// otherwise it is applied to Val. The result is stored and the Nullable is marked as valid and present.
+func (n *Nullable[T]) Accumulate(f func(cur T) T) {
+	if !n.Valid {
+		n.Val = zeroValue[T]()
+	}
+
+	n.Val = f(n.Val)
+	n.Valid = true
+	n.Present = true
+}
+
+// ValuePtr returns a pointer to the Val field of the receiver if the Nullable is present, and nil if it is absent.
+// The pointer aliases the Nullable, so writes through it update Val in place without copying, even for present nulls,
+// but they don't change the Valid flag. The pointer stays tied to the Nullable it was taken from:
+// it must not be retained once that Nullable is copied, reassigned or discarded.
+func (n *Nullable[T]) ValuePtr() *T {
+	if !n.Present {
+		return nil
+	}
+	return &n.Val
+}
+
+// Hash returns a stable 64-bit FNV-1a hash of the Nullable, so it can be used as a map or set key component.
+// Invalid Nullables all hash to NullHash, while valid ones hash their validity together with the type and the
+// binary representation of Val, so a null and a valid zero value don't collide. Values that are == hash the same,
+// e.g. -0.0 and 0.0, and pointers hash by address. All NaNs hash the same, although they are never ==.
+// It returns an error if T, or the dynamic type of an interface in Val, is not comparable.
+func (n Nullable[T]) Hash() (uint64, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if !t.Comparable() {
+		return 0, fmt.Errorf("cannot hash Nullable of non-comparable type %s", t)
+	}
+	if !n.Valid {
+		return NullHash, nil
+	}
+
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "valid:%s:", t)
+	if err := hashValue(h, reflect.ValueOf(&n.Val).Elem()); err != nil {
+		return 0, err
+	}
+	return h.Sum64(), nil
+}
+
+// hashValue writes a canonical binary encoding of v to h, which is the same for values that are ==.
+func hashValue(h hash.Hash64, v reflect.Value) error {
+	var buf [8]byte
+	writeUint := func(u uint64) {
+		binary.LittleEndian.PutUint64(buf[:], u)
+		_, _ = h.Write(buf[:])
+	}
+	writeFloat := func(f float64) {
+		switch {
+		case f == 0:
+			f = 0 // -0.0 == 0.0
+		case math.IsNaN(f):
+			f = math.NaN()
+		}
+		writeUint(math.Float64bits(f))
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			writeUint(1)
+		} else {
+			writeUint(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		writeFloat(real(v.Complex()))
+		writeFloat(imag(v.Complex()))
+	case reflect.String:
+		writeUint(uint64(v.Len()))
+		_, _ = h.Write([]byte(v.String()))
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint(uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			writeUint(0)
+			return nil
+		}
+		_, _ = fmt.Fprintf(h, "%s:", v.Elem().Type())
+		return hashValue(h, v.Elem())
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := hashValue(h, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if err := hashValue(h, v.Field(i)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("cannot hash value of non-comparable type %s", v.Type())
+	}
+	return nil
+}
+
 // zeroValue is a helper function that returns the zero value for the generic type T.
 // It is used to set the zero value for the Val field of the Nullable struct when the value is nil.
 func zeroValue[T any]() T {
@@ -174,25 +693,110 @@ func zeroValue[T any]() T {
 
 // convertToType is a helper function that attempts to convert the given value to type T.
 // This function is used by Scan to properly handle value conversion, ensuring that Nullable values are always of the correct type.
+//
+// Conversions are attempted in a fixed order and the first one that applies wins:
+//  1. converters registered with RegisterConverter
+//  2. io.Reader streams and PostgreSQL bytea text
+//  3. identical types and text parsed by a Parser
+//  4. interface targets, see SetAmbiguousOrder for []byte
+//  5. dereferenced pointers
+//  6. well-known types such as time.Time and net.IP
+//  7. []any values, PostgreSQL arrays and JSON documents
+//  8. named byte slices and booleans
+//  9. numeric text and numeric values
 func convertToType[T any](value any) (T, error) {
 	var zero T
 	if value == nil {
 		return zero, nil
 	}
 
+	if convert, ok := lookup[T, func(any) (T, error)](&converters); ok {
+		if converted, err := convert(value); !errors.Is(err, ErrUnsupportedConversion) {
+			return converted, err
+		}
+	}
+
+	if converted, ok, err := convertFromReader[T](value); ok {
+		return converted, err
+	}
+
+	// Textual bytea output has to be decoded before the raw []byte is taken as is.
+	if converted, ok, err := convertFromBytea[T](value); ok {
+		return converted, err
+	}
+
 	valueType := reflect.TypeOf(value)
-	targetType := reflect.TypeOf(zero)
+	targetType := reflect.TypeOf((*T)(nil)).Elem()
 	if valueType == targetType {
 		return value.(T), nil
 	}
 
-	isNumeric := func(kind reflect.Kind) bool {
-		return kind >= reflect.Int && kind <= reflect.Float64
+	if converted, ok, err := convertWithParser[T](value); ok {
+		return converted, err
+	}
+
+	// Interface targets accept any value implementing them as is, unless a []byte is resolved by SetAmbiguousOrder.
+	if targetType.Kind() == reflect.Interface && valueType.AssignableTo(targetType) {
+		if converted, ok := convertAmbiguousBytes[T](value); ok {
+			return converted, nil
+		}
+		return value.(T), nil
+	}
+
+	// Pointers to supported types, which some drivers return for nullable columns, are converted from what they point to.
+	// If that's unsupported, the pointer itself is converted, e.g. a type implementing fmt.Stringer with a pointer receiver.
+	if valueType.Kind() == reflect.Pointer {
+		rv := reflect.ValueOf(value)
+		if rv.IsNil() {
+			return zero, nil
+		}
+		if converted, err := convertToType[T](rv.Elem().Interface()); !errors.Is(err, ErrUnsupportedConversion) {
+			return converted, err
+		}
+	}
+
+	if converted, ok, err := convertToKnownType[T](value); ok {
+		return converted, err
+	}
+
+	if converted, ok, err := convertFromAnySlice[T](value); ok {
+		return converted, err
+	}
+
+	if converted, ok, err := convertFromPostgresArray[T](value); ok {
+		return converted, err
+	}
+
+	if converted, ok, err := convertFromJSON[T](value); ok {
+		return converted, err
+	}
+
+	if converted, ok := convertToNamedBytes[T](value); ok {
+		return converted, nil
+	}
+
+	if converted, ok, err := convertToBool[T](value); ok {
+		return converted, err
+	}
+
+	if converted, ok := convertFromBool[T](value); ok {
+		return converted, nil
+	}
+
+	if converted, ok, err := convertFromJSONNumber[T](value); ok {
+		return converted, err
+	}
+
+	if converted, ok, err := convertFromNumericText[T](value); ok {
+		return converted, err
 	}
 
 	// Check if the value is a numeric type and if T is also a numeric type.
-	if isNumeric(valueType.Kind()) && isNumeric(targetType.Kind()) {
-		convertedValue := reflect.ValueOf(value).Convert(targetType)
+	if isNumericKind(valueType) && isNumericKind(targetType) {
+		convertedValue, err := convertNumeric(reflect.ValueOf(value), targetType)
+		if err != nil {
+			return zero, err
+		}
 		return convertedValue.Interface().(T), nil
 	}
 
@@ -1,11 +1,16 @@
 package gonull
 
 import (
+	"bytes"
+	"context"
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"html/template"
+	"math"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -47,7 +52,7 @@ func TestNullableScan(t *testing.T) {
 		},
 		{
 			name:    "unsupported type",
-			value:   []byte{1, 2, 3},
+			value:   struct{}{},
 			wantErr: true,
 			Present: true,
 		},
@@ -614,3 +619,606 @@ func TestNullableValue_Uint32(t *testing.T) {
 		t.Errorf("Nullable[uint32].Value() returned %v, want %v", convertedValue, uint32Val)
 	}
 }
+
+func TestNullableSetClearUnset(t *testing.T) {
+	var n Nullable[string]
+
+	n.Set("hello")
+	assert.Equal(t, NewNullable("hello"), n)
+
+	n.Clear()
+	assert.Equal(t, Nullable[string]{Present: true}, n)
+
+	n.Set("again")
+	n.Unset()
+	assert.Equal(t, Nullable[string]{}, n)
+
+	data, err := json.Marshal(n)
+	assert.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+}
+
+func TestNullableGetOrInsert(t *testing.T) {
+	calls := 0
+	compute := func() int {
+		calls++
+		return 42
+	}
+
+	var n Nullable[int]
+	assert.Equal(t, 42, n.GetOrInsert(compute))
+	assert.Equal(t, 42, n.GetOrInsert(compute))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, NewNullable(42), n)
+
+	valid := NewNullable(7)
+	assert.Equal(t, 7, valid.GetOrInsert(compute))
+	assert.Equal(t, 1, calls)
+}
+
+func TestNullableAccumulate(t *testing.T) {
+	sum := Nullable[int]{Val: 99, Present: true}
+	for _, v := range []int{3, 4, 5} {
+		v := v
+		sum.Accumulate(func(cur int) int { return cur + v })
+	}
+	assert.Equal(t, NewNullable(12), sum)
+
+	var words Nullable[[]string]
+	words.Accumulate(func(cur []string) []string { return append(cur, "a") })
+	words.Accumulate(func(cur []string) []string { return append(cur, "b") })
+	assert.True(t, words.Valid)
+	assert.Equal(t, []string{"a", "b"}, words.Val)
+}
+
+func TestNullableEnsure(t *testing.T) {
+	assert.NoError(t, NewNullable(1).Ensure())
+	assert.NoError(t, Nullable[int]{Present: true}.Ensure())
+	assert.NoError(t, Nullable[int]{}.Ensure())
+
+	err := Nullable[int]{Val: 1, Valid: true}.Ensure()
+	assert.ErrorIs(t, err, ErrInconsistentState)
+	assert.EqualError(t, err, "inconsistent Nullable state: Nullable[int] is valid but not present")
+}
+
+func TestNullablePatchOp(t *testing.T) {
+	defer SetPatchNullMode(PatchNullRemove)
+
+	op, ok := NewNullable("Ana").PatchOp("/name")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]any{"op": "replace", "path": "/name", "value": "Ana"}, op)
+
+	null := Nullable[string]{Val: "stale", Present: true}
+	op, ok = null.PatchOp("/name")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]any{"op": "remove", "path": "/name"}, op)
+
+	SetPatchNullMode(PatchNullReplace)
+	op, ok = null.PatchOp("/name")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]any{"op": "replace", "path": "/name", "value": nil}, op)
+
+	op, ok = Nullable[string]{}.PatchOp("/name")
+	assert.False(t, ok)
+	assert.Nil(t, op)
+}
+
+// textLevel implements encoding.TextMarshaler and encoding.TextUnmarshaler with its own names.
+type textLevel int
+
+func (l textLevel) MarshalText() ([]byte, error) {
+	return []byte([]string{"low", "high"}[l]), nil
+}
+
+func (l *textLevel) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 0
+	case "high":
+		*l = 1
+	default:
+		return fmt.Errorf("unknown level %q", text)
+	}
+	return nil
+}
+
+type textName string
+
+func TestNullableMarshalText(t *testing.T) {
+	text, err := NewNullable(42).MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "42", string(text))
+
+	text, err = NewNullable(textLevel(1)).MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "high", string(text))
+
+	text, err = NewNullable(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)).MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-05-01T12:00:00Z", string(text))
+
+	text, err = Nullable[int]{Val: 7, Present: true}.MarshalText()
+	assert.NoError(t, err)
+	assert.Empty(t, text)
+	assert.NotNil(t, text)
+}
+
+func TestNullableUnmarshalText(t *testing.T) {
+	var i Nullable[int]
+	assert.NoError(t, i.UnmarshalText([]byte("42")))
+	assert.Equal(t, NewNullable(42), i)
+
+	assert.NoError(t, i.UnmarshalText([]byte{}))
+	assert.Equal(t, Nullable[int]{Present: true}, i)
+
+	assert.Error(t, i.UnmarshalText([]byte("x")))
+	assert.True(t, i.Present)
+	assert.False(t, i.Valid)
+
+	var level Nullable[textLevel]
+	assert.NoError(t, level.UnmarshalText([]byte("high")))
+	assert.Equal(t, NewNullable(textLevel(1)), level)
+	assert.EqualError(t, level.UnmarshalText([]byte("medium")), `unknown level "medium"`)
+
+	var name Nullable[textName]
+	assert.NoError(t, name.UnmarshalText([]byte("gopher")))
+	assert.Equal(t, NewNullable(textName("gopher")), name)
+
+	var ts Nullable[time.Time]
+	assert.NoError(t, ts.UnmarshalText([]byte("2024-05-01T12:00:00Z")))
+	assert.Equal(t, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), ts.Val)
+
+	// JSON keeps using MarshalJSON and UnmarshalJSON.
+	data, err := json.Marshal(NewNullable(textLevel(0)))
+	assert.NoError(t, err)
+	assert.Equal(t, `"low"`, string(data))
+	data, err = json.Marshal(Nullable[int]{Present: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+}
+
+// pointerMarshaler implements json.Marshaler on its pointer receiver only.
+type pointerMarshaler struct {
+	id int
+}
+
+func (p *pointerMarshaler) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"id-%d"`, p.id)), nil
+}
+
+func TestNullableMarshalJSONPointerReceiver(t *testing.T) {
+	data, err := json.Marshal(NewNullable(pointerMarshaler{id: 7}))
+	assert.NoError(t, err)
+	assert.Equal(t, `"id-7"`, string(data))
+
+	data, err = json.Marshal(Nullable[pointerMarshaler]{Val: pointerMarshaler{id: 7}, Present: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+
+	var buf bytes.Buffer
+	assert.NoError(t, NewNullable(pointerMarshaler{id: 8}).MarshalJSONWith(json.NewEncoder(&buf)))
+	assert.Equal(t, `"id-8"`+"\n", buf.String())
+
+	data, err = json.Marshal(NewNullable(&pointerMarshaler{id: 9}))
+	assert.NoError(t, err)
+	assert.Equal(t, `"id-9"`, string(data))
+}
+
+func TestNullableMarshalJSONWith(t *testing.T) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	err := NewNullable("<a href=\"/?x=1&y=2\">").MarshalJSONWith(enc)
+	assert.NoError(t, err)
+	assert.Equal(t, `"<a href=\"/?x=1&y=2\">"`+"\n", buf.String())
+
+	buf.Reset()
+	err = Nullable[string]{Present: true}.MarshalJSONWith(enc)
+	assert.NoError(t, err)
+	assert.Equal(t, "null\n", buf.String())
+
+	buf.Reset()
+	enc.SetIndent("", "  ")
+	err = NewNullable(map[string]int{"a": 1}).MarshalJSONWith(enc)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"a\": 1\n}\n", buf.String())
+
+	buf.Reset()
+	err = json.NewEncoder(&buf).Encode(NewNullable("<b>"))
+	assert.NoError(t, err)
+	assert.Equal(t, `"\u003cb\u003e"`+"\n", buf.String())
+}
+
+func TestNullableGet(t *testing.T) {
+	value, ok := NewNullable("hello").Get()
+	assert.True(t, ok)
+	assert.Equal(t, "hello", value)
+
+	value, ok = Nullable[string]{Val: "stale", Present: true}.Get()
+	assert.False(t, ok)
+	assert.Equal(t, "", value)
+
+	number, ok := Nullable[int]{Val: 7}.Get()
+	assert.False(t, ok)
+	assert.Equal(t, 0, number)
+}
+
+func TestNullableOrZero(t *testing.T) {
+	assert.Equal(t, "hello", NewNullable("hello").OrZero())
+	assert.Equal(t, "", Nullable[string]{Val: "stale", Present: true}.OrZero())
+	assert.Equal(t, 0, Nullable[int]{Val: 7}.OrZero())
+	assert.Nil(t, Nullable[[]int]{Val: []int{1}}.OrZero())
+}
+
+func TestNullableMustGet(t *testing.T) {
+	assert.Equal(t, "hello", NewNullable("hello").MustGet())
+
+	assert.PanicsWithValue(t, "gonull: MustGet called on invalid Nullable[string]", func() {
+		Nullable[string]{Val: "stale", Present: true}.MustGet()
+	})
+	assert.PanicsWithValue(t, "gonull: MustGet called on invalid Nullable[time.Time]", func() {
+		Nullable[time.Time]{}.MustGet()
+	})
+}
+
+// slowValuer records its conversions and honors cancellation while serializing.
+type slowValuer struct {
+	calls *int
+}
+
+func (s slowValuer) Value() (driver.Value, error) {
+	*s.calls++
+	return "serialized", nil
+}
+
+func (s slowValuer) ValueContext(ctx context.Context) (driver.Value, error) {
+	*s.calls++
+	return "serialized with context", ctx.Err()
+}
+
+func TestNullableValueContext(t *testing.T) {
+	calls := 0
+	n := NewNullable(slowValuer{calls: &calls})
+
+	value, err := n.ValueContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "serialized with context", value)
+	assert.Equal(t, 1, calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	value, err = n.ValueContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, value)
+	assert.Equal(t, 1, calls)
+
+	value, err = NewNullable(int32(5)).ValueContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), value)
+
+	value, err = Nullable[string]{Present: true}.ValueContext(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestFromPtr(t *testing.T) {
+	value := "hello"
+	n := FromPtr(&value)
+	assert.Equal(t, NewNullable("hello"), n)
+
+	value = "changed"
+	assert.Equal(t, "hello", n.Val)
+
+	assert.Equal(t, Nullable[int]{Present: true}, FromPtr[int](nil))
+
+	assert.Equal(t, NewNullable(42), FromPtr(NewNullable(42).Ptr()))
+}
+
+func TestNullablePtr(t *testing.T) {
+	n := NewNullable("hello")
+	ptr := n.Ptr()
+	if assert.NotNil(t, ptr) {
+		assert.Equal(t, "hello", *ptr)
+		*ptr = "changed"
+	}
+	assert.Equal(t, "hello", n.Val)
+	assert.NotSame(t, n.Ptr(), n.Ptr())
+
+	assert.Nil(t, Nullable[string]{Val: "stale", Present: true}.Ptr())
+	assert.Nil(t, Nullable[int]{}.Ptr())
+}
+
+func TestNullableRequire(t *testing.T) {
+	value, err := NewNullable("required").Require()
+	assert.NoError(t, err)
+	assert.Equal(t, "required", value)
+
+	value, err = Nullable[string]{}.Require()
+	assert.ErrorIs(t, err, ErrAbsent)
+	assert.Equal(t, "", value)
+
+	value, err = Nullable[string]{Val: "stale", Present: true}.Require()
+	assert.ErrorIs(t, err, ErrNull)
+	assert.Equal(t, "", value)
+}
+
+type pointerValuerScanner struct {
+	parts []string
+}
+
+func (p *pointerValuerScanner) Value() (driver.Value, error) {
+	return strings.Join(p.parts, "|"), nil
+}
+
+func (p *pointerValuerScanner) Scan(src any) error {
+	s, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("unsupported type: %T", src)
+	}
+	p.parts = strings.Split(s, "|")
+	return nil
+}
+
+func TestValuerScannerRoundTrip(t *testing.T) {
+	original := NewNullable(pointerValuerScanner{parts: []string{"a", "b", "c"}})
+
+	stored, err := original.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "a|b|c", stored, "pointer-receiver Value must be used")
+
+	var restored Nullable[pointerValuerScanner]
+	err = restored.Scan(stored)
+	assert.NoError(t, err)
+	assert.Equal(t, original, restored)
+
+	stored, err = Nullable[pointerValuerScanner]{Present: true}.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, stored)
+
+	err = restored.Scan(stored)
+	assert.NoError(t, err)
+	assert.False(t, restored.Valid)
+}
+
+func TestNullableDisplay(t *testing.T) {
+	assert.Equal(t, 42, NewNullable(42).Display("N/A"))
+	assert.Equal(t, "N/A", Nullable[int]{Present: true}.Display("N/A"))
+
+	tmpl := template.Must(template.New("display").Parse(`{{ .Name.Display "N/A" }} / {{ .Age.Display "unknown" }}`))
+	var buf strings.Builder
+	err := tmpl.Execute(&buf, struct {
+		Name Nullable[string]
+		Age  Nullable[int]
+	}{Name: NewNullable("<Alice>")})
+	assert.NoError(t, err)
+	assert.Equal(t, "&lt;Alice&gt; / unknown", buf.String())
+}
+
+func TestNullableValuePtr(t *testing.T) {
+	n := NewNullable([]int{1, 2})
+	ptr := n.ValuePtr()
+	assert.NotNil(t, ptr)
+	*ptr = append(*ptr, 3)
+	assert.Equal(t, []int{1, 2, 3}, n.Val)
+
+	null := Nullable[int]{Present: true}
+	ptr2 := null.ValuePtr()
+	assert.NotNil(t, ptr2)
+	*ptr2 = 5
+	assert.Equal(t, 5, null.Val)
+	assert.False(t, null.Valid)
+
+	var absent Nullable[int]
+	assert.Nil(t, absent.ValuePtr())
+}
+
+func TestNullableJSONString(t *testing.T) {
+	assert.Equal(t, `"hello"`, NewNullable("hello").JSONString())
+	assert.Equal(t, `{"a":1}`, NewNullable(map[string]int{"a": 1}).JSONString())
+	assert.Equal(t, "null", Nullable[string]{Present: true}.JSONString())
+
+	failing := NewNullable(math.NaN())
+	_, err := json.Marshal(failing.Val)
+	assert.Error(t, err)
+	assert.Equal(t, err.Error(), failing.JSONString())
+}
+
+func TestNullableOrValue(t *testing.T) {
+	assert.Equal(t, NewNullable("hello"), NewNullable("hello").OrValue("world"))
+	assert.Equal(t, NewNullable("world"), Nullable[string]{Present: true}.OrValue("world"))
+	assert.Equal(t, NewNullable("world"), Nullable[string]{}.OrValue("world"))
+}
+
+func TestNullableMapErr(t *testing.T) {
+	normalize := func(s string) (string, error) {
+		if s == "" {
+			return "", errors.New("empty")
+		}
+		return strings.ToUpper(s), nil
+	}
+
+	assert.Equal(t, NewNullable("GE"), NewNullable("ge").MapErr(normalize))
+	assert.Equal(t, Nullable[string]{Present: true}, NewNullable("").MapErr(normalize))
+
+	invalid := Nullable[string]{Val: "stale"}
+	assert.Equal(t, invalid, invalid.MapErr(normalize))
+}
+
+func TestNullableSchemaType(t *testing.T) {
+	type schemaTyper interface {
+		SchemaType() (reflect.Type, bool)
+	}
+
+	tests := []struct {
+		name     string
+		nullable schemaTyper
+		want     reflect.Type
+	}{
+		{name: "string", nullable: Nullable[string]{}, want: reflect.TypeOf("")},
+		{name: "int64", nullable: NewNullable[int64](1), want: reflect.TypeOf(int64(0))},
+		{name: "time", nullable: Nullable[time.Time]{}, want: reflect.TypeOf(time.Time{})},
+		{name: "pointer", nullable: Nullable[*string]{}, want: reflect.TypeOf((*string)(nil))},
+		{name: "slice", nullable: Nullable[[]int]{}, want: reflect.TypeOf([]int(nil))},
+		{name: "interface", nullable: Nullable[any]{}, want: reflect.TypeOf((*any)(nil)).Elem()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner, nullable := tt.nullable.SchemaType()
+			assert.Equal(t, tt.want, inner)
+			assert.True(t, nullable)
+		})
+	}
+}
+
+func TestNullableBytesJSONBase64(t *testing.T) {
+	type payload struct {
+		Data Nullable[[]byte] `json:"data"`
+	}
+
+	raw := []byte{0x00, 0xff, 'g', 'o'}
+	data, err := json.Marshal(payload{Data: NewNullable(raw)})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"data":"AP9nbw=="}`, string(data))
+
+	data, err = json.Marshal(payload{Data: Nullable[[]byte]{Present: true}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"data":null}`, string(data))
+
+	var decoded payload
+	err = json.Unmarshal([]byte(`{"data":"AP9nbw=="}`), &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, NewNullable(raw), decoded.Data)
+
+	decoded = payload{}
+	err = json.Unmarshal([]byte(`{"data":null}`), &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, Nullable[[]byte]{Present: true}, decoded.Data)
+
+	err = json.Unmarshal([]byte(`{"data":"not base64!"}`), &decoded)
+	assert.Error(t, err)
+}
+
+func TestNullableTap(t *testing.T) {
+	var seen []string
+	onValid := func(v int) { seen = append(seen, fmt.Sprintf("valid:%d", v)) }
+	onInvalid := func() { seen = append(seen, "invalid") }
+
+	valid := NewNullable(1)
+	assert.Equal(t, valid, valid.Tap(onValid, onInvalid))
+
+	invalid := Nullable[int]{Val: 9, Present: true}
+	assert.Equal(t, invalid, invalid.Tap(onValid, onInvalid))
+
+	assert.Equal(t, []string{"valid:1", "invalid"}, seen)
+
+	assert.Equal(t, valid, valid.Tap(nil, nil))
+	assert.Equal(t, invalid, invalid.Tap(nil, nil))
+}
+
+func TestNullableZeroToNull(t *testing.T) {
+	assert.Equal(t, Nullable[string]{Present: true}, NewNullable("").ZeroToNull())
+	assert.Equal(t, Nullable[int]{Present: true}, NewNullable(0).ZeroToNull())
+	assert.Equal(t, Nullable[time.Time]{Present: true}, NewNullable(time.Time{}).ZeroToNull())
+
+	assert.Equal(t, NewNullable("value"), NewNullable("value").ZeroToNull())
+	assert.Equal(t, NewNullable(7), NewNullable(7).ZeroToNull())
+
+	absent := Nullable[int]{}
+	assert.Equal(t, absent, absent.ZeroToNull())
+}
+
+func TestNullableHash(t *testing.T) {
+	null, err := Nullable[int]{Present: true}.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, NullHash, null)
+
+	absent, err := Nullable[int]{}.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, NullHash, absent)
+
+	zero, err := NewNullable(0).Hash()
+	assert.NoError(t, err)
+	assert.NotEqual(t, null, zero, "null and valid zero must not collide")
+
+	emptyString, err := NewNullable("").Hash()
+	assert.NoError(t, err)
+	assert.NotEqual(t, null, emptyString)
+	assert.NotEqual(t, zero, emptyString)
+
+	a, err := NewNullable("value").Hash()
+	assert.NoError(t, err)
+	b, err := NewNullable("value").Hash()
+	assert.NoError(t, err)
+	c, err := NewNullable("other").Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+
+	_, err = NewNullable([]int{1}).Hash()
+	assert.Error(t, err)
+	_, err = NewNullable[any]([]int{1}).Hash()
+	assert.Error(t, err)
+}
+
+func TestNullableHashSignedZero(t *testing.T) {
+	negativeZero := math.Copysign(0, -1)
+	assert.True(t, negativeZero == 0)
+
+	pos, err := NewNullable(0.0).Hash()
+	assert.NoError(t, err)
+	neg, err := NewNullable(negativeZero).Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, pos, neg)
+
+	type point struct{ X, Y float32 }
+	pos, err = NewNullable(point{X: 1}).Hash()
+	assert.NoError(t, err)
+	neg, err = NewNullable(point{X: 1, Y: float32(negativeZero)}).Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, pos, neg)
+
+	one, err := NewNullable(point{X: 1, Y: 1}).Hash()
+	assert.NoError(t, err)
+	assert.NotEqual(t, pos, one)
+}
+
+func TestNullableCompareTo(t *testing.T) {
+	cmp := func(a, b int) int { return a - b }
+	null := Nullable[int]{Present: true}
+
+	_, ok := null.CompareTo(NewNullable(1), cmp)
+	assert.False(t, ok)
+	_, ok = NewNullable(1).CompareTo(null, cmp)
+	assert.False(t, ok)
+	_, ok = null.CompareTo(null, cmp)
+	assert.False(t, ok)
+
+	result, ok := NewNullable(1).CompareTo(NewNullable(3), cmp)
+	assert.True(t, ok)
+	assert.Negative(t, result)
+
+	result, ok = NewNullable(3).CompareTo(NewNullable(3), cmp)
+	assert.True(t, ok)
+	assert.Zero(t, result)
+
+	result, ok = NewNullable("b").CompareTo(NewNullable("a"), strings.Compare)
+	assert.True(t, ok)
+	assert.Equal(t, 1, result)
+}
+
+func TestNullableWithPresentAndValid(t *testing.T) {
+	presentNull := NewNullable("x").WithValid(false)
+	assert.Equal(t, Nullable[string]{Val: "x", Present: true}, presentNull)
+
+	absent := NewNullable("x").WithValid(false).WithPresent(false)
+	assert.Equal(t, Nullable[string]{Val: "x"}, absent)
+
+	valid := Nullable[string]{Val: "x"}.WithPresent(true).WithValid(true)
+	assert.Equal(t, NewNullable("x"), valid)
+
+	original := NewNullable(1)
+	_ = original.WithValid(false)
+	assert.True(t, original.Valid, "builders must return copies")
+}
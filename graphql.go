@@ -0,0 +1,38 @@
+package gonull
+
+import "io"
+
+// MarshalGQL implements the graphql.Marshaler interface of github.com/99designs/gqlgen for Nullable,
+// so Nullable types can be used directly as GraphQL scalar fields.
+// Valid values are written as their JSON representation and invalid values are written as null.
+// Since the interface can't report errors, a value that fails to encode is also written as null.
+func (n Nullable[T]) MarshalGQL(w io.Writer) {
+	data, err := n.MarshalJSON()
+	if err != nil {
+		data = []byte("null")
+	}
+	_, _ = w.Write(data)
+}
+
+// UnmarshalGQL implements the graphql.Unmarshaler interface of github.com/99designs/gqlgen for Nullable.
+// An input that is provided marks the Nullable as present, and a GraphQL null leaves it invalid.
+// Inputs missing from the query never reach UnmarshalGQL, so the Nullable stays absent.
+func (n *Nullable[T]) UnmarshalGQL(v any) error {
+	n.Present = true
+
+	if v == nil {
+		n.Val = zeroValue[T]()
+		n.Valid = false
+		return nil
+	}
+
+	value, err := convertDecoded[T](v)
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+
+	n.Val = normalize(value)
+	n.Valid = true
+	return nil
+}
@@ -0,0 +1,57 @@
+package gonull
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type gqlInput struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func TestNullableMarshalGQL(t *testing.T) {
+	var buf bytes.Buffer
+	NewNullable("gopher").MarshalGQL(&buf)
+	assert.Equal(t, `"gopher"`, buf.String())
+
+	buf.Reset()
+	Nullable[int]{Present: true}.MarshalGQL(&buf)
+	assert.Equal(t, "null", buf.String())
+
+	buf.Reset()
+	NewNullable(42).MarshalGQL(&buf)
+	assert.Equal(t, "42", buf.String())
+}
+
+func TestNullableUnmarshalGQL(t *testing.T) {
+	var s Nullable[string]
+	assert.NoError(t, s.UnmarshalGQL("gopher"))
+	assert.Equal(t, NewNullable("gopher"), s)
+
+	var i Nullable[int]
+	assert.NoError(t, i.UnmarshalGQL(int64(7)))
+	assert.Equal(t, NewNullable(7), i)
+
+	assert.NoError(t, i.UnmarshalGQL(json.Number("9")))
+	assert.Equal(t, 9, i.Val)
+
+	assert.NoError(t, i.UnmarshalGQL(nil))
+	assert.True(t, i.Present)
+	assert.False(t, i.Valid)
+	assert.Equal(t, 0, i.Val)
+
+	var obj Nullable[gqlInput]
+	assert.NoError(t, obj.UnmarshalGQL(map[string]any{"name": "Ana", "age": int64(30)}))
+	assert.Equal(t, gqlInput{Name: "Ana", Age: 30}, obj.Val)
+
+	var list Nullable[[]string]
+	assert.NoError(t, list.UnmarshalGQL([]any{"a", "b"}))
+	assert.Equal(t, []string{"a", "b"}, list.Val)
+
+	assert.Error(t, i.UnmarshalGQL([]any{"x"}))
+	assert.False(t, i.Valid)
+}
@@ -0,0 +1,177 @@
+package gonull
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Convert applies the fallible converter f to the value of n and returns the result as a Nullable[U].
+// If n is not valid, f is not called and an invalid Nullable[U] is returned with the Present flag preserved.
+// Any error returned by f is propagated together with an invalid, present Nullable[U].
+func Convert[T, U any](n Nullable[T], f func(T) (U, error)) (Nullable[U], error) {
+	if !n.Valid {
+		return Nullable[U]{Present: n.Present}, nil
+	}
+
+	value, err := f(n.Val)
+	if err != nil {
+		return Nullable[U]{Present: true}, err
+	}
+
+	return NewNullable(value), nil
+}
+
+// Map applies f to the value of n and returns the result as a valid Nullable[U].
+// If n is not valid, f is not called and an invalid Nullable[U] is returned with the Present flag preserved.
+//
+// It is a function rather than a method because Go methods can't declare type parameters of their own.
+func Map[T, U any](n Nullable[T], f func(T) U) Nullable[U] {
+	if !n.Valid {
+		return Nullable[U]{Present: n.Present}
+	}
+
+	return NewNullable(f(n.Val))
+}
+
+// Chain invokes the given producers in order and returns the first valid Nullable.
+// Producers are called lazily, so the ones following the first valid result are never invoked.
+// If no producer yields a valid Nullable, an invalid and absent Nullable is returned.
+func Chain[T any](producers ...func() Nullable[T]) Nullable[T] {
+	for _, produce := range producers {
+		if n := produce(); n.Valid {
+			return n
+		}
+	}
+
+	return Nullable[T]{}
+}
+
+// FilterPresent returns a new map containing only the entries of m that are present, including present nulls.
+// This is useful to serialize partial maps where absent entries should be left out entirely.
+func FilterPresent[T any](m map[string]Nullable[T]) map[string]Nullable[T] {
+	filtered := make(map[string]Nullable[T], len(m))
+	for key, n := range m {
+		if n.Present {
+			filtered[key] = n
+		}
+	}
+
+	return filtered
+}
+
+// MapString applies the string operation f, e.g. strings.ToLower or strings.TrimSpace, to the value of n if it is valid
+// and passes invalid values through unchanged. It works with any type whose underlying type is string.
+//
+// It is a function rather than a method because Go methods can't be declared for a single instantiation of Nullable.
+func MapString[S ~string](n Nullable[S], f func(string) string) Nullable[S] {
+	if !n.Valid {
+		return n
+	}
+
+	n.Val = S(f(string(n.Val)))
+	return n
+}
+
+// Bind applies f to the value of n if it is valid and returns both the Nullable and the error produced by f.
+// This composes stages that can fail as well as yield an invalid result. If n is not valid, f is not called and
+// an invalid Nullable[U] is returned with the Present flag preserved.
+func Bind[T, U any](n Nullable[T], f func(T) (Nullable[U], error)) (Nullable[U], error) {
+	if !n.Valid {
+		return Nullable[U]{Present: n.Present}, nil
+	}
+
+	return f(n.Val)
+}
+
+// FlatMapErr chains the fallible, Nullable-returning function f onto n. It is the same operation as Bind,
+// named after the FlatMap of Option types: invalid input short-circuits to an invalid Nullable[U]
+// with the Present flag preserved and a nil error, and otherwise the result and error of f are returned.
+func FlatMapErr[T, U any](n Nullable[T], f func(T) (Nullable[U], error)) (Nullable[U], error) {
+	return Bind(n, f)
+}
+
+// Try calls f and returns a valid Nullable holding its result on success. If f returns an error,
+// the error is discarded and a present but invalid Nullable is returned, which suits getters where
+// an error just means the value is not available, e.g. a cache miss.
+func Try[T any](f func() (T, error)) Nullable[T] {
+	n, _ := TryErr(f)
+	return n
+}
+
+// TryErr is like Try but also returns the error produced by f.
+func TryErr[T any](f func() (T, error)) (Nullable[T], error) {
+	value, err := f()
+	if err != nil {
+		return Nullable[T]{Present: true}, err
+	}
+
+	return NewNullable(value), nil
+}
+
+// Agree returns a valid Nullable only if a and b are both valid and hold equal values.
+// Otherwise it returns a present but invalid Nullable, signaling a conflict or a missing value
+// between two sources that should match.
+func Agree[T comparable](a, b Nullable[T]) Nullable[T] {
+	if a.Valid && b.Valid && a.Val == b.Val {
+		return a
+	}
+
+	return Nullable[T]{Present: true}
+}
+
+// CoalesceMap applies f to the value of the first valid Nullable in vals and returns the result as a valid Nullable[U].
+// f is called at most once, with the chosen value only. If none of vals is valid, an invalid and absent Nullable[U] is returned.
+func CoalesceMap[T, U any](f func(T) U, vals ...Nullable[T]) Nullable[U] {
+	for _, n := range vals {
+		if n.Valid {
+			return NewNullable(f(n.Val))
+		}
+	}
+
+	return Nullable[U]{}
+}
+
+// CollectScan scans each of values into a Nullable[T]. It returns one Nullable per value, in order, so indexes line up
+// with the input, together with the errors of all values that failed to scan joined with errors.Join.
+// Failed elements are present but invalid, while the successfully scanned ones are returned as usual.
+func CollectScan[T any](values []any) ([]Nullable[T], error) {
+	result := make([]Nullable[T], len(values))
+	var errs []error
+	for i, value := range values {
+		if err := result[i].Scan(value); err != nil {
+			errs = append(errs, fmt.Errorf("value %d: %w", i, err))
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// EqualFloat reports whether a and b are equal within epsilon. Two invalid Nullables are equal regardless of
+// their Present flags, a valid and an invalid one never are, and two valid ones are equal when their values
+// differ by at most epsilon or are identical, which covers infinities of the same sign. NaN is not equal to anything.
+func EqualFloat[T ~float32 | ~float64](a, b Nullable[T], epsilon T) bool {
+	if !a.Valid || !b.Valid {
+		return a.Valid == b.Valid
+	}
+	if a.Val == b.Val {
+		return true
+	}
+
+	diff := a.Val - b.Val
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= epsilon
+}
+
+// CoalescePtr returns the first Nullable in vals that is valid and holds a non-nil pointer, treating a valid nil pointer
+// as null. If there is none, an invalid and absent Nullable is returned.
+func CoalescePtr[T any](vals ...Nullable[*T]) Nullable[*T] {
+	for _, n := range vals {
+		if n.Valid && n.Val != nil {
+			return n
+		}
+	}
+
+	return Nullable[*T]{}
+}
@@ -0,0 +1,284 @@
+package gonull
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvert(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		result, err := Convert(NewNullable("42"), strconv.Atoi)
+		assert.NoError(t, err)
+		assert.Equal(t, NewNullable(42), result)
+	})
+
+	t.Run("conversion error on valid value", func(t *testing.T) {
+		result, err := Convert(NewNullable("forty-two"), strconv.Atoi)
+		assert.Error(t, err)
+		assert.False(t, result.Valid)
+		assert.True(t, result.Present)
+	})
+
+	t.Run("passthrough on invalid", func(t *testing.T) {
+		called := false
+		f := func(s string) (int, error) {
+			called = true
+			return 0, errors.New("should not be called")
+		}
+
+		result, err := Convert(Nullable[string]{Present: true}, f)
+		assert.NoError(t, err)
+		assert.False(t, called)
+		assert.Equal(t, Nullable[int]{Present: true}, result)
+
+		result, err = Convert(Nullable[string]{}, f)
+		assert.NoError(t, err)
+		assert.False(t, called)
+		assert.Equal(t, Nullable[int]{}, result)
+	})
+}
+
+func TestMap(t *testing.T) {
+	calls := 0
+	format := func(v int) string {
+		calls++
+		return strconv.Itoa(v)
+	}
+
+	assert.Equal(t, NewNullable("42"), Map(NewNullable(42), format))
+	assert.Equal(t, Nullable[string]{Present: true}, Map(Nullable[int]{Val: 1, Present: true}, format))
+	assert.Equal(t, Nullable[string]{}, Map(Nullable[int]{}, format))
+	assert.Equal(t, 1, calls)
+}
+
+func TestChain(t *testing.T) {
+	var calls []string
+	producer := func(name string, n Nullable[string]) func() Nullable[string] {
+		return func() Nullable[string] {
+			calls = append(calls, name)
+			return n
+		}
+	}
+
+	t.Run("stops at first valid", func(t *testing.T) {
+		calls = nil
+		result := Chain(
+			producer("env", Nullable[string]{}),
+			producer("file", NewNullable("from file")),
+			producer("default", NewNullable("default")),
+		)
+		assert.Equal(t, NewNullable("from file"), result)
+		assert.Equal(t, []string{"env", "file"}, calls)
+	})
+
+	t.Run("no valid producer", func(t *testing.T) {
+		calls = nil
+		result := Chain(
+			producer("env", Nullable[string]{}),
+			producer("file", Nullable[string]{Present: true}),
+		)
+		assert.Equal(t, Nullable[string]{}, result)
+		assert.Equal(t, []string{"env", "file"}, calls)
+	})
+
+	t.Run("no producers", func(t *testing.T) {
+		assert.Equal(t, Nullable[int]{}, Chain[int]())
+	})
+}
+
+func TestFilterPresent(t *testing.T) {
+	m := map[string]Nullable[int]{
+		"valid":  NewNullable(1),
+		"null":   {Present: true},
+		"absent": {},
+	}
+
+	filtered := FilterPresent(m)
+	assert.Equal(t, map[string]Nullable[int]{
+		"valid": NewNullable(1),
+		"null":  {Present: true},
+	}, filtered)
+	assert.Len(t, m, 3, "input map must not be modified")
+
+	assert.Empty(t, FilterPresent[int](nil))
+}
+
+func TestMapString(t *testing.T) {
+	assert.Equal(t, NewNullable("hello"), MapString(NewNullable("HeLLo"), strings.ToLower))
+
+	invalid := Nullable[string]{Val: "STALE", Present: true}
+	assert.Equal(t, invalid, MapString(invalid, strings.ToLower))
+
+	type code string
+	assert.Equal(t, NewNullable[code]("ab"), MapString(NewNullable[code](" ab "), strings.TrimSpace))
+}
+
+func TestBind(t *testing.T) {
+	parsePositive := func(s string) (Nullable[int], error) {
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return Nullable[int]{Present: true}, err
+		}
+		if i <= 0 {
+			return Nullable[int]{Present: true}, nil
+		}
+		return NewNullable(i), nil
+	}
+
+	t.Run("valid success", func(t *testing.T) {
+		result, err := Bind(NewNullable("7"), parsePositive)
+		assert.NoError(t, err)
+		assert.Equal(t, NewNullable(7), result)
+
+		result, err = Bind(NewNullable("-7"), parsePositive)
+		assert.NoError(t, err)
+		assert.Equal(t, Nullable[int]{Present: true}, result)
+	})
+
+	t.Run("valid error", func(t *testing.T) {
+		result, err := Bind(NewNullable("seven"), parsePositive)
+		assert.Error(t, err)
+		assert.Equal(t, Nullable[int]{Present: true}, result)
+	})
+
+	t.Run("invalid passthrough", func(t *testing.T) {
+		called := false
+		result, err := Bind(Nullable[string]{Present: true}, func(string) (Nullable[int], error) {
+			called = true
+			return NewNullable(1), nil
+		})
+		assert.NoError(t, err)
+		assert.False(t, called)
+		assert.Equal(t, Nullable[int]{Present: true}, result)
+	})
+}
+
+func TestFlatMapErr(t *testing.T) {
+	errNegative := errors.New("negative")
+	sqrt := func(v float64) (Nullable[float64], error) {
+		if v < 0 {
+			return Nullable[float64]{Present: true}, errNegative
+		}
+		return NewNullable(math.Sqrt(v)), nil
+	}
+
+	result, err := FlatMapErr(NewNullable(16.0), sqrt)
+	assert.NoError(t, err)
+	assert.Equal(t, NewNullable(4.0), result)
+
+	result, err = FlatMapErr(NewNullable(-1.0), sqrt)
+	assert.ErrorIs(t, err, errNegative)
+	assert.Equal(t, Nullable[float64]{Present: true}, result)
+
+	result, err = FlatMapErr(Nullable[float64]{Present: true}, sqrt)
+	assert.NoError(t, err)
+	assert.Equal(t, Nullable[float64]{Present: true}, result)
+
+	result, err = FlatMapErr(Nullable[float64]{}, sqrt)
+	assert.NoError(t, err)
+	assert.Equal(t, Nullable[float64]{}, result)
+}
+
+func TestTry(t *testing.T) {
+	errMiss := errors.New("cache miss")
+	hit := func() (string, error) { return "cached", nil }
+	miss := func() (string, error) { return "partial", errMiss }
+
+	assert.Equal(t, NewNullable("cached"), Try(hit))
+	assert.Equal(t, Nullable[string]{Present: true}, Try(miss))
+
+	n, err := TryErr(hit)
+	assert.NoError(t, err)
+	assert.Equal(t, NewNullable("cached"), n)
+
+	n, err = TryErr(miss)
+	assert.ErrorIs(t, err, errMiss)
+	assert.Equal(t, Nullable[string]{Present: true}, n)
+}
+
+func TestAgree(t *testing.T) {
+	assert.Equal(t, NewNullable("GE"), Agree(NewNullable("GE"), NewNullable("GE")))
+	assert.Equal(t, Nullable[string]{Present: true}, Agree(NewNullable("GE"), NewNullable("US")))
+	assert.Equal(t, Nullable[string]{Present: true}, Agree(NewNullable("GE"), Nullable[string]{}))
+	assert.Equal(t, Nullable[string]{Present: true}, Agree(Nullable[string]{Present: true}, NewNullable("GE")))
+	assert.Equal(t, Nullable[string]{Present: true}, Agree(Nullable[string]{}, Nullable[string]{}))
+}
+
+func TestCoalesceMap(t *testing.T) {
+	var seen []int
+	port := func(v int) string {
+		seen = append(seen, v)
+		return fmt.Sprintf(":%d", v)
+	}
+
+	result := CoalesceMap(port, Nullable[int]{}, Nullable[int]{Present: true}, NewNullable(8080), NewNullable(9090))
+	assert.Equal(t, NewNullable(":8080"), result)
+	assert.Equal(t, []int{8080}, seen)
+
+	result = CoalesceMap(port, Nullable[int]{}, Nullable[int]{Val: 1, Present: true})
+	assert.Equal(t, Nullable[string]{}, result)
+	assert.Equal(t, []int{8080}, seen)
+
+	assert.Equal(t, Nullable[string]{}, CoalesceMap[int](port))
+}
+
+func TestCollectScan(t *testing.T) {
+	result, err := CollectScan[int]([]any{int64(1), "2", nil, "x", struct{}{}})
+	assert.Len(t, result, 5)
+	assert.Equal(t, NewNullable(1), result[0])
+	assert.Equal(t, NewNullable(2), result[1])
+	assert.Equal(t, Nullable[int]{Present: true}, result[2])
+	assert.False(t, result[3].Valid)
+	assert.False(t, result[4].Valid)
+
+	assert.ErrorIs(t, err, ErrUnsupportedConversion)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.Contains(t, err.Error(), "value 3: ")
+	assert.Contains(t, err.Error(), "value 4: ")
+	assert.NotContains(t, err.Error(), "value 2")
+
+	texts, err := CollectScan[string]([]any{"a", nil})
+	assert.NoError(t, err)
+	assert.Equal(t, []Nullable[string]{NewNullable("a"), {Present: true}}, texts)
+}
+
+func TestEqualFloat(t *testing.T) {
+	a, b := 0.1, 0.2
+	assert.True(t, EqualFloat(NewNullable(a+b), NewNullable(0.3), 1e-9))
+	assert.False(t, EqualFloat(NewNullable(a+b), NewNullable(0.3), 0))
+	assert.True(t, EqualFloat(NewNullable(1.0), NewNullable(1.5), 0.5))
+	assert.False(t, EqualFloat(NewNullable(1.0), NewNullable(1.6), 0.5))
+	assert.False(t, EqualFloat(NewNullable(1.6), NewNullable(1.0), 0.5))
+
+	assert.True(t, EqualFloat(Nullable[float32]{}, Nullable[float32]{Val: 1, Present: true}, 0.1))
+	assert.False(t, EqualFloat(NewNullable[float32](0), Nullable[float32]{}, 0.1))
+	assert.False(t, EqualFloat(Nullable[float32]{Present: true}, NewNullable[float32](0), 0.1))
+
+	nan := NewNullable(math.NaN())
+	assert.False(t, EqualFloat(nan, nan, 1))
+
+	inf, negInf := NewNullable(math.Inf(1)), NewNullable(math.Inf(-1))
+	assert.True(t, EqualFloat(inf, inf, 0.1))
+	assert.True(t, EqualFloat(negInf, negInf, 0))
+	assert.False(t, EqualFloat(inf, negInf, 0.1))
+	assert.False(t, EqualFloat(inf, NewNullable(math.MaxFloat64), 0.1))
+}
+
+func TestCoalescePtr(t *testing.T) {
+	first, second := "first", "second"
+
+	result := CoalescePtr(Nullable[*string]{}, NewNullable[*string](nil), NewNullable(&first), NewNullable(&second))
+	assert.True(t, result.Valid)
+	assert.Same(t, &first, result.Val)
+
+	result = CoalescePtr(Nullable[*string]{Val: &second, Present: true}, NewNullable[*string](nil))
+	assert.Equal(t, Nullable[*string]{}, result)
+
+	assert.Equal(t, Nullable[*string]{}, CoalescePtr[string]())
+}
@@ -0,0 +1,21 @@
+//go:build go1.23
+
+package gonull
+
+import "iter"
+
+// All returns an iterator that yields Val once if the Nullable is valid and nothing otherwise,
+// so the value can be handled with a range loop instead of checking Valid:
+//
+//	for v := range n.All() {
+//		use(v)
+//	}
+//
+// This file is only built with Go 1.23 or later.
+func (n Nullable[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n.Valid {
+			yield(n.Val)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+//go:build go1.23
+
+package gonull
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNullableAll(t *testing.T) {
+	var got []string
+	for v := range NewNullable("once").All() {
+		got = append(got, v)
+	}
+	assert.Equal(t, []string{"once"}, got)
+
+	for v := range (Nullable[string]{Val: "stale", Present: true}).All() {
+		t.Errorf("unexpected value %q", v)
+	}
+	for v := range (Nullable[string]{}).All() {
+		t.Errorf("unexpected value %q", v)
+	}
+
+	for range NewNullable(1).All() {
+		break
+	}
+}
@@ -0,0 +1,62 @@
+package gonull
+
+import (
+	"database/sql/driver"
+	"sync"
+)
+
+// MemoizedNullable wraps a Nullable and caches the result of its Value method, which saves recomputing
+// expensive driver.Valuer conversions, e.g. serializing a large struct, when the same value is passed
+// to the database several times, as happens with retries. The wrapped Nullable can only be changed
+// through Set, which invalidates the cache. It is safe for concurrent use.
+type MemoizedNullable[T any] struct {
+	mu     sync.Mutex
+	n      Nullable[T]
+	value  driver.Value
+	cached bool
+}
+
+// Memoize returns a MemoizedNullable wrapping a copy of the Nullable.
+func (n Nullable[T]) Memoize() *MemoizedNullable[T] {
+	return &MemoizedNullable[T]{n: n}
+}
+
+// Value implements the driver.Valuer interface. The value is computed by Nullable.Value on the first call
+// and returned from the cache afterwards. Errors are not cached, so a failed conversion is retried on the next call.
+func (m *MemoizedNullable[T]) Value() (driver.Value, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.cached {
+		return m.value, nil
+	}
+
+	value, err := m.n.Value()
+	if err != nil {
+		return nil, err
+	}
+	m.value, m.cached = value, true
+	return value, nil
+}
+
+// Set replaces the wrapped Nullable with a valid one holding value and invalidates the cached driver value.
+func (m *MemoizedNullable[T]) Set(value T) {
+	m.Reset(NewNullable(value))
+}
+
+// Reset replaces the wrapped Nullable with n and invalidates the cached driver value.
+func (m *MemoizedNullable[T]) Reset(n Nullable[T]) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.n = n
+	m.value, m.cached = nil, false
+}
+
+// Nullable returns a copy of the wrapped Nullable.
+func (m *MemoizedNullable[T]) Nullable() Nullable[T] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.n
+}
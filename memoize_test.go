@@ -0,0 +1,57 @@
+package gonull
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingValuer counts how many times its Value method is called.
+type countingValuer struct {
+	name  string
+	calls *int
+}
+
+func (c countingValuer) Value() (driver.Value, error) {
+	*c.calls++
+	if c.name == "" {
+		return nil, errors.New("empty name")
+	}
+	return fmt.Sprintf("serialized:%s", c.name), nil
+}
+
+func TestNullableMemoize(t *testing.T) {
+	calls := 0
+	m := NewNullable(countingValuer{name: "a", calls: &calls}).Memoize()
+
+	for i := 0; i < 3; i++ {
+		value, err := m.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "serialized:a", value)
+	}
+	assert.Equal(t, 1, calls)
+
+	m.Set(countingValuer{name: "b", calls: &calls})
+	value, err := m.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "serialized:b", value)
+	_, _ = m.Value()
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, "b", m.Nullable().Val.name)
+
+	m.Reset(Nullable[countingValuer]{Present: true})
+	value, err = m.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+	assert.Equal(t, 2, calls)
+
+	m.Set(countingValuer{calls: &calls})
+	_, err = m.Value()
+	assert.Error(t, err)
+	_, err = m.Value()
+	assert.Error(t, err)
+	assert.Equal(t, 4, calls)
+}
@@ -0,0 +1,27 @@
+//go:build mo
+
+package gonull
+
+import "github.com/samber/mo"
+
+// ToMoOption converts the Nullable into an mo.Option of github.com/samber/mo.
+// A valid Nullable becomes Some holding Val, and an invalid one becomes None.
+// The Present flag has no equivalent in mo.Option and is dropped.
+//
+// This file is only built with the "mo" build tag.
+func (n Nullable[T]) ToMoOption() mo.Option[T] {
+	if !n.Valid {
+		return mo.None[T]()
+	}
+	return mo.Some(n.Val)
+}
+
+// FromMoOption creates a Nullable from an mo.Option of github.com/samber/mo. Some becomes a valid Nullable,
+// and None becomes a present but invalid one, like a nil pointer passed to FromPtr.
+func FromMoOption[T any](o mo.Option[T]) Nullable[T] {
+	value, ok := o.Get()
+	if !ok {
+		return Nullable[T]{Present: true}
+	}
+	return NewNullable(value)
+}
@@ -0,0 +1,29 @@
+//go:build mo
+
+package gonull
+
+import (
+	"testing"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNullableToMoOption(t *testing.T) {
+	some := NewNullable("gopher").ToMoOption()
+	assert.True(t, some.IsPresent())
+	assert.Equal(t, "gopher", some.MustGet())
+
+	none := Nullable[string]{Val: "stale", Present: true}.ToMoOption()
+	assert.True(t, none.IsAbsent())
+	assert.True(t, Nullable[int]{}.ToMoOption().IsAbsent())
+}
+
+func TestFromMoOption(t *testing.T) {
+	assert.Equal(t, NewNullable(42), FromMoOption(mo.Some(42)))
+	assert.Equal(t, Nullable[int]{Present: true}, FromMoOption(mo.None[int]()))
+
+	n := NewNullable("round trip")
+	assert.Equal(t, n, FromMoOption(n.ToMoOption()))
+	assert.Equal(t, Nullable[string]{Present: true}, FromMoOption(Nullable[string]{}.ToMoOption()))
+}
@@ -0,0 +1,87 @@
+package gonull
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ScanAll reads all remaining rows into a slice of T, where T is a struct whose fields, typically Nullables,
+// are mapped to result columns by their `db` tag, falling back to the `json` tag name and then the field name.
+// Matching is case-insensitive, fields tagged `db:"-"` are skipped and fields of embedded structs are promoted.
+// Columns without a matching field are discarded, and fields without a matching column are left untouched,
+// so their Nullables stay absent. The rows are closed before ScanAll returns.
+func ScanAll[T any](rows *sql.Rows) ([]T, error) {
+	defer rows.Close()
+
+	var zero T
+	rt := reflect.TypeOf(zero)
+	if rt == nil || rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("ScanAll: unsupported target type %T, expected a struct", zero)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := columnFields(rt, nil, map[string][]int{})
+
+	var result []T
+	for rows.Next() {
+		var item T
+		rv := reflect.ValueOf(&item).Elem()
+
+		dest := make([]any, len(columns))
+		for i, column := range columns {
+			if index, ok := fields[strings.ToLower(column)]; ok {
+				dest[i] = rv.FieldByIndex(index).Addr().Interface()
+			} else {
+				dest[i] = new(any)
+			}
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+// columnFields maps lower-cased column names to the index of the struct field they are scanned into.
+func columnFields(rt reflect.Type, parent []int, fields map[string][]int) map[string][]int {
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		index := append(append([]int{}, parent...), i)
+
+		tag, hasTag := field.Tag.Lookup("db")
+		if tag == "-" {
+			continue
+		}
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && !hasTag {
+			columnFields(field.Type, index, fields)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if name == "" {
+			name = strings.Split(field.Tag.Get("json"), ",")[0]
+		}
+		if name == "" || name == "-" {
+			name = field.Name
+		}
+
+		if _, exists := fields[strings.ToLower(name)]; !exists {
+			fields[strings.ToLower(name)] = index
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,88 @@
+package gonull
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+type scannedAudit struct {
+	CreatedBy Nullable[string] `db:"created_by"`
+}
+
+type scannedUser struct {
+	ID      int64            `db:"id"`
+	Name    Nullable[string] `db:"name"`
+	Age     Nullable[int]    `json:"age"`
+	Email   Nullable[string]
+	Country Nullable[string] `db:"country"`
+	Ignored Nullable[string] `db:"-"`
+	scannedAudit
+}
+
+func TestScanAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "name", "AGE", "email", "created_by", "extra"}).
+		AddRow(int64(1), "Alice", int64(30), "alice@example.com", "admin", "x").
+		AddRow(int64(2), nil, nil, "bob@example.com", nil, nil)
+	mock.ExpectQuery("SELECT").WillReturnRows(rows).RowsWillBeClosed()
+
+	sqlRows, err := db.Query("SELECT * FROM users")
+	assert.NoError(t, err)
+
+	users, err := ScanAll[scannedUser](sqlRows)
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+
+	assert.Equal(t, int64(1), users[0].ID)
+	assert.Equal(t, NewNullable("Alice"), users[0].Name)
+	assert.Equal(t, NewNullable(30), users[0].Age)
+	assert.Equal(t, NewNullable("alice@example.com"), users[0].Email)
+	assert.Equal(t, NewNullable("admin"), users[0].CreatedBy)
+
+	assert.Equal(t, int64(2), users[1].ID)
+	assert.Equal(t, Nullable[string]{Present: true}, users[1].Name)
+	assert.Equal(t, Nullable[int]{Present: true}, users[1].Age)
+	assert.Equal(t, Nullable[string]{Present: true}, users[1].CreatedBy)
+
+	for _, user := range users {
+		assert.False(t, user.Country.Present, "missing column leaves the field absent")
+		assert.False(t, user.Ignored.Present)
+	}
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestScanAll_ScanError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"age"}).AddRow("not a number")
+	mock.ExpectQuery("SELECT").WillReturnRows(rows)
+
+	sqlRows, err := db.Query("SELECT age FROM users")
+	assert.NoError(t, err)
+
+	users, err := ScanAll[scannedUser](sqlRows)
+	assert.Error(t, err)
+	assert.Nil(t, users)
+}
+
+func TestScanAll_NonStruct(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
+
+	sqlRows, err := db.Query("SELECT id FROM users")
+	assert.NoError(t, err)
+
+	_, err = ScanAll[int](sqlRows)
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package gonull
+
+import (
+	"database/sql/driver"
+	"strings"
+)
+
+// NullableSet is a nullable set of strings, typically used for MySQL SET columns,
+// which the driver returns as a comma-joined string such as "a,c".
+// It embeds Nullable[[]string] and overrides Scan and Value to split and join the members.
+type NullableSet struct {
+	Nullable[[]string]
+}
+
+// NewNullableSet creates a new valid NullableSet with the given members.
+func NewNullableSet(members ...string) NullableSet {
+	if members == nil {
+		members = []string{}
+	}
+	return NullableSet{Nullable: NewNullable(members)}
+}
+
+// Scan implements the sql.Scanner interface for NullableSet.
+// NULL marks the set as invalid, an empty string produces a valid empty set and
+// any other string or []byte is split on commas.
+func (s *NullableSet) Scan(value any) error {
+	if value == nil {
+		return s.Nullable.Scan(nil)
+	}
+
+	text, ok := asText(value)
+	if !ok {
+		s.Present = true
+		s.Val = nil
+		s.Valid = false
+		return ErrUnsupportedConversion
+	}
+
+	members := []string{}
+	if text != "" {
+		members = strings.Split(text, ",")
+	}
+
+	s.Val = members
+	s.Valid = true
+	s.Present = true
+	return nil
+}
+
+// Value implements the driver.Valuer interface for NullableSet, joining the members with commas.
+func (s NullableSet) Value() (driver.Value, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	return strings.Join(s.Val, ","), nil
+}
+
+// Contains reports whether the set is valid and holds the given member.
+func (s NullableSet) Contains(member string) bool {
+	if !s.Valid {
+		return false
+	}
+	for _, m := range s.Val {
+		if m == member {
+			return true
+		}
+	}
+	return false
+}
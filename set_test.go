@@ -0,0 +1,58 @@
+package gonull
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNullableSetScan(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     any
+		want      []string
+		wantValid bool
+		wantValue any
+	}{
+		{name: "multiple values", value: "a,c", want: []string{"a", "c"}, wantValid: true, wantValue: "a,c"},
+		{name: "single value bytes", value: []byte("b"), want: []string{"b"}, wantValid: true, wantValue: "b"},
+		{name: "empty", value: "", want: []string{}, wantValid: true, wantValue: ""},
+		{name: "null", value: nil, want: nil, wantValid: false, wantValue: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s NullableSet
+			err := s.Scan(tt.value)
+			assert.NoError(t, err)
+			assert.True(t, s.Present)
+			assert.Equal(t, tt.wantValid, s.Valid)
+			assert.Equal(t, tt.want, s.Val)
+
+			value, err := s.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantValue, value)
+		})
+	}
+}
+
+func TestNullableSetScan_Unsupported(t *testing.T) {
+	var s NullableSet
+	err := s.Scan(int64(1))
+	assert.ErrorIs(t, err, ErrUnsupportedConversion)
+	assert.False(t, s.Valid)
+	assert.True(t, s.Present)
+}
+
+func TestNullableSetContains(t *testing.T) {
+	s := NewNullableSet("read", "write")
+	assert.True(t, s.Contains("read"))
+	assert.False(t, s.Contains("admin"))
+
+	var invalid NullableSet
+	assert.False(t, invalid.Contains("read"))
+
+	empty := NewNullableSet()
+	assert.True(t, empty.Valid)
+	assert.Equal(t, []string{}, empty.Val)
+}
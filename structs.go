@@ -0,0 +1,197 @@
+package gonull
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// ErrNotStruct is returned by the struct helpers when they are given something other than a struct or a pointer to one.
+var ErrNotStruct = errors.New("value is not a struct")
+
+// nullableState is implemented by every Nullable instantiation. It lets the reflection-based struct helpers
+// recognize Nullable fields regardless of their element type.
+type nullableState interface {
+	state() (valid, present bool)
+}
+
+func (n Nullable[T]) state() (valid, present bool) {
+	return n.Valid, n.Present
+}
+
+var nullableStateType = reflect.TypeOf((*nullableState)(nil)).Elem()
+
+// isNullable reports whether t is a Nullable type.
+func isNullable(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.Implements(nullableStateType)
+}
+
+// MergeStructs applies the Nullable fields of patch onto base following JSON Merge Patch (RFC 7396) semantics.
+// base must be a pointer to a struct and patch a struct or a pointer to a struct of the same type.
+// For every Nullable field, a present value in patch, valid or null, replaces the field in base,
+// while an absent one leaves base unchanged. Nested structs are merged recursively and other fields are ignored,
+// since they can't express absence.
+func MergeStructs(base, patch any) error {
+	baseValue := reflect.ValueOf(base)
+	if baseValue.Kind() != reflect.Pointer || baseValue.IsNil() || baseValue.Elem().Kind() != reflect.Struct {
+		return errors.New("MergeStructs: base must be a non-nil pointer to a struct")
+	}
+
+	patchValue := reflect.Indirect(reflect.ValueOf(patch))
+	if patchValue.Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+	if patchValue.Type() != baseValue.Elem().Type() {
+		return errors.New("MergeStructs: base and patch must be of the same type")
+	}
+
+	mergeStruct(baseValue.Elem(), patchValue)
+	return nil
+}
+
+func mergeStruct(base, patch reflect.Value) {
+	for i := 0; i < base.NumField(); i++ {
+		field := base.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+
+		patchField := patch.Field(i)
+		switch {
+		case isNullable(field.Type()):
+			if _, present := patchField.Interface().(nullableState).state(); present {
+				field.Set(patchField)
+			}
+		case field.Kind() == reflect.Struct:
+			mergeStruct(field, patchField)
+		}
+	}
+}
+
+// PresentFields returns the JSON names of all Nullable fields of v that are present, in field order.
+// v must be a struct or a pointer to one. Fields of embedded structs are included as if they were declared on v,
+// matching how encoding/json flattens them. This is useful to build column lists for SQL UPDATE statements.
+func PresentFields(v any) ([]string, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, ErrNotStruct
+	}
+
+	var names []string
+	walkNullables(rv, "", false, func(name string, field reflect.Value) {
+		if _, present := field.Interface().(nullableState).state(); present {
+			names = append(names, name)
+		}
+	})
+	return names, nil
+}
+
+// CountStates tallies the Nullable fields of v by state: valid, present but null, and absent.
+// v must be a struct or a pointer to one. Nested and embedded structs are counted as well.
+func CountStates(v any) (valid, null, absent int, err error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return 0, 0, 0, ErrNotStruct
+	}
+
+	walkNullables(rv, "", true, func(_ string, field reflect.Value) {
+		switch isValid, present := field.Interface().(nullableState).state(); {
+		case isValid:
+			valid++
+		case present:
+			null++
+		default:
+			absent++
+		}
+	})
+	return valid, null, absent, nil
+}
+
+// RedactInvalid sets Val to the zero value for every invalid Nullable field of v, including those of nested structs,
+// so no stale data remains in memory where it could be inspected or logged. Flags are left unchanged.
+// v must be a non-nil pointer to a struct.
+func RedactInvalid(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("RedactInvalid: v must be a non-nil pointer to a struct")
+	}
+
+	walkNullables(rv.Elem(), "", true, func(_ string, field reflect.Value) {
+		if valid, _ := field.Interface().(nullableState).state(); !valid {
+			val := field.FieldByName("Val")
+			val.Set(reflect.Zero(val.Type()))
+		}
+	})
+	return nil
+}
+
+// Snapshot captures the Val, Valid and Present fields of every Nullable field of v, including those of nested structs,
+// and returns a function restoring them, e.g. to roll back a tentative update that failed validation.
+// The capture is shallow: values sharing memory, such as slices or maps, are not deep-copied.
+// v must be a non-nil pointer to a struct.
+func Snapshot(v any) (restore func(), err error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, errors.New("Snapshot: v must be a non-nil pointer to a struct")
+	}
+
+	type savedField struct {
+		field, saved reflect.Value
+	}
+
+	var fields []savedField
+	walkNullables(rv.Elem(), "", true, func(_ string, field reflect.Value) {
+		saved := reflect.New(field.Type()).Elem()
+		saved.Set(field)
+		fields = append(fields, savedField{field: field, saved: saved})
+	})
+
+	return func() {
+		for _, f := range fields {
+			f.field.Set(f.saved)
+		}
+	}, nil
+}
+
+// walkNullables calls fn for every exported Nullable field of the struct rv with the field's JSON name.
+// Embedded structs are always walked. Other struct fields are walked only when nested is true,
+// in which case the names of their fields are prefixed with the parent name, e.g. "address.city".
+func walkNullables(rv reflect.Value, prefix string, nested bool, fn func(name string, field reflect.Value)) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		structField := rt.Field(i)
+		field := rv.Field(i)
+
+		name, tagged := jsonFieldName(structField)
+		if name == "-" {
+			continue
+		}
+
+		if structField.Anonymous && !tagged && !isNullable(structField.Type) && structField.Type.Kind() == reflect.Struct {
+			walkNullables(field, prefix, nested, fn)
+			continue
+		}
+		if !structField.IsExported() {
+			continue
+		}
+
+		switch {
+		case isNullable(structField.Type):
+			fn(prefix+name, field)
+		case nested && structField.Type.Kind() == reflect.Struct:
+			walkNullables(field, prefix+name+".", nested, fn)
+		}
+	}
+}
+
+// jsonFieldName returns the name encoding/json uses for the field and whether it was set by a tag.
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "-", true
+	}
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name, true
+	}
+	return field.Name, false
+}
@@ -0,0 +1,155 @@
+package gonull
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mergeAddress struct {
+	City Nullable[string] `json:"city"`
+	Zip  Nullable[string] `json:"zip"`
+}
+
+type mergeProfile struct {
+	Name    Nullable[string] `json:"name"`
+	Age     Nullable[int]    `json:"age"`
+	Email   Nullable[string] `json:"email"`
+	Address mergeAddress     `json:"address"`
+	Version int              `json:"version"`
+}
+
+func TestMergeStructs(t *testing.T) {
+	base := mergeProfile{
+		Name:    NewNullable("Alice"),
+		Age:     NewNullable(30),
+		Email:   NewNullable("alice@example.com"),
+		Address: mergeAddress{City: NewNullable("Tbilisi"), Zip: NewNullable("0100")},
+		Version: 1,
+	}
+
+	var patch mergeProfile
+	err := json.Unmarshal([]byte(`{"name":"Alicia","email":null,"address":{"zip":null}}`), &patch)
+	assert.NoError(t, err)
+
+	err = MergeStructs(&base, patch)
+	assert.NoError(t, err)
+
+	assert.Equal(t, NewNullable("Alicia"), base.Name, "present-valid overwrites")
+	assert.Equal(t, Nullable[string]{Present: true}, base.Email, "present-null clears")
+	assert.Equal(t, NewNullable(30), base.Age, "absent leaves base")
+	assert.Equal(t, NewNullable("Tbilisi"), base.Address.City)
+	assert.Equal(t, Nullable[string]{Present: true}, base.Address.Zip)
+	assert.Equal(t, 1, base.Version)
+}
+
+func TestMergeStructs_Errors(t *testing.T) {
+	var base mergeProfile
+
+	assert.Error(t, MergeStructs(base, mergeProfile{}))
+	assert.Error(t, MergeStructs(&base, mergeAddress{}))
+	assert.ErrorIs(t, MergeStructs(&base, 42), ErrNotStruct)
+	assert.NoError(t, MergeStructs(&base, &mergeProfile{}))
+}
+
+type presentAudit struct {
+	UpdatedBy Nullable[string] `json:"updated_by"`
+	Reason    Nullable[string] `json:"reason"`
+}
+
+type presentUser struct {
+	Name     Nullable[string] `json:"name"`
+	Age      Nullable[int]    `json:"age"`
+	Email    Nullable[string]
+	Password Nullable[string] `json:"-"`
+	Address  mergeAddress     `json:"address"`
+	presentAudit
+}
+
+func TestPresentFields(t *testing.T) {
+	var user presentUser
+	err := json.Unmarshal([]byte(`{"name":"Alice","age":null,"updated_by":"admin","address":{"city":"Tbilisi"}}`), &user)
+	assert.NoError(t, err)
+	user.Email = NewNullable("alice@example.com")
+	user.Password = NewNullable("secret")
+
+	fields, err := PresentFields(&user)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"name", "age", "Email", "updated_by"}, fields)
+
+	fields, err = PresentFields(presentUser{})
+	assert.NoError(t, err)
+	assert.Empty(t, fields)
+
+	_, err = PresentFields("not a struct")
+	assert.ErrorIs(t, err, ErrNotStruct)
+}
+
+func TestCountStates(t *testing.T) {
+	profile := mergeProfile{
+		Name:    NewNullable("Alice"),
+		Age:     Nullable[int]{Present: true},
+		Address: mergeAddress{City: NewNullable("Tbilisi")},
+	}
+
+	valid, null, absent, err := CountStates(&profile)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, valid)
+	assert.Equal(t, 1, null)
+	assert.Equal(t, 2, absent)
+
+	valid, null, absent, err = CountStates(struct {
+		A Nullable[int]
+		B Nullable[int]
+		C Nullable[int]
+	}{A: NewNullable(1), B: Nullable[int]{Present: true}})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 1, 1}, []int{valid, null, absent})
+
+	_, _, _, err = CountStates(nil)
+	assert.ErrorIs(t, err, ErrNotStruct)
+}
+
+func TestRedactInvalid(t *testing.T) {
+	profile := mergeProfile{
+		Name:    NewNullable("Alice"),
+		Age:     Nullable[int]{Val: 30, Present: true},
+		Email:   Nullable[string]{Val: "stale@example.com"},
+		Address: mergeAddress{City: Nullable[string]{Val: "Tbilisi", Present: true}},
+	}
+
+	err := RedactInvalid(&profile)
+	assert.NoError(t, err)
+	assert.Equal(t, NewNullable("Alice"), profile.Name)
+	assert.Equal(t, Nullable[int]{Present: true}, profile.Age)
+	assert.Equal(t, Nullable[string]{}, profile.Email)
+	assert.Equal(t, Nullable[string]{Present: true}, profile.Address.City)
+
+	assert.Error(t, RedactInvalid(profile))
+	assert.Error(t, RedactInvalid((*mergeProfile)(nil)))
+}
+
+func TestSnapshot(t *testing.T) {
+	profile := mergeProfile{
+		Name:    NewNullable("Alice"),
+		Age:     Nullable[int]{Present: true},
+		Address: mergeAddress{City: NewNullable("Tbilisi")},
+		Version: 1,
+	}
+	original := profile
+
+	restore, err := Snapshot(&profile)
+	assert.NoError(t, err)
+
+	profile.Name = NewNullable("Mallory")
+	profile.Age = NewNullable(99)
+	profile.Email = Nullable[string]{Present: true}
+	profile.Address.City = Nullable[string]{}
+
+	restore()
+	assert.Equal(t, original, profile)
+
+	_, err = Snapshot(profile)
+	assert.Error(t, err)
+}
@@ -0,0 +1,155 @@
+//go:build toml
+
+package gonull
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ErrTOMLNull is returned when a present Nullable that is not valid is encoded to TOML, unless EncodeTOML
+// is used in TOMLNullAbsent mode.
+var ErrTOMLNull = errors.New("TOML cannot represent a null value")
+
+// tomlNullable is implemented by every Nullable, so that EncodeTOML can find null values of any type.
+type tomlNullable interface {
+	isTOMLNull() bool
+}
+
+// MarshalTOML implements the toml.Marshaler interface of github.com/BurntSushi/toml for Nullable.
+// Valid values are written the same way the encoder writes T, and invalid values return ErrTOMLNull.
+// Absent Nullables can be left out of the document by tagging the field with `toml:",omitempty"`,
+// because the encoder skips zero structs before MarshalTOML is called.
+// Only values that TOML can write inline (scalars, datetimes and arrays) are supported.
+//
+// This file is only built with the "toml" build tag.
+func (n Nullable[T]) MarshalTOML() ([]byte, error) {
+	if !n.Valid {
+		return nil, ErrTOMLNull
+	}
+
+	return marshalTOMLValue(n.Val)
+}
+
+// isTOMLNull reports whether n is a present null, which EncodeTOML drops in TOMLNullAbsent mode.
+func (n Nullable[T]) isTOMLNull() bool {
+	return n.Present && !n.Valid
+}
+
+// EncodeTOML writes v to w as a TOML document using github.com/BurntSushi/toml.
+// The TOML encoder writes a key before calling MarshalTOML, so null values can't leave themselves out.
+// In TOMLNullAbsent mode EncodeTOML therefore encodes a copy of v in which null values are made absent,
+// which drops them from maps and from struct fields tagged `toml:",omitempty"`. In TOMLNullError mode it
+// behaves like toml.NewEncoder(w).Encode(v).
+func EncodeTOML(w io.Writer, v any) error {
+	if loadSettings().tomlNullMode == TOMLNullAbsent {
+		if rv := reflect.ValueOf(v); rv.IsValid() {
+			v = omitTOMLNulls(rv).Interface()
+		}
+	}
+	return toml.NewEncoder(w).Encode(v)
+}
+
+// omitTOMLNulls returns a copy of rv in which present null Nullables are replaced with absent ones.
+// Map entries holding null Nullables are removed.
+func omitTOMLNulls(rv reflect.Value) reflect.Value {
+	if isTOMLNull(rv) {
+		return reflect.Zero(rv.Type())
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return rv
+		}
+		out := reflect.New(rv.Type().Elem())
+		out.Elem().Set(omitTOMLNulls(rv.Elem()))
+		return out
+	case reflect.Interface:
+		if rv.IsNil() {
+			return rv
+		}
+		return omitTOMLNulls(rv.Elem())
+	case reflect.Struct:
+		if _, ok := rv.Interface().(tomlNullable); ok {
+			return rv
+		}
+		out := reflect.New(rv.Type()).Elem()
+		out.Set(rv)
+		for i := 0; i < out.NumField(); i++ {
+			if field := out.Field(i); field.CanSet() {
+				field.Set(omitTOMLNulls(rv.Field(i)))
+			}
+		}
+		return out
+	case reflect.Map:
+		if rv.IsNil() {
+			return rv
+		}
+		out := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		for iter := rv.MapRange(); iter.Next(); {
+			if !isTOMLNull(iter.Value()) {
+				out.SetMapIndex(iter.Key(), omitTOMLNulls(iter.Value()))
+			}
+		}
+		return out
+	case reflect.Slice:
+		if rv.IsNil() {
+			return rv
+		}
+		out := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			out.Index(i).Set(omitTOMLNulls(rv.Index(i)))
+		}
+		return out
+	}
+	return rv
+}
+
+// isTOMLNull reports whether rv holds a present null Nullable.
+func isTOMLNull(rv reflect.Value) bool {
+	if rv.Kind() == reflect.Interface && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct || !rv.CanInterface() {
+		return false
+	}
+	null, ok := rv.Interface().(tomlNullable)
+	return ok && null.isTOMLNull()
+}
+
+// UnmarshalTOML implements the toml.Unmarshaler interface of github.com/BurntSushi/toml for Nullable.
+// A key present in the document marks the Nullable as present and valid. Keys missing from the document
+// never reach UnmarshalTOML, so the Nullable stays absent.
+func (n *Nullable[T]) UnmarshalTOML(data any) error {
+	n.Present = true
+
+	value, err := convertDecoded[T](data)
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+
+	n.Val = normalize(value)
+	n.Valid = true
+	return nil
+}
+
+// marshalTOMLValue encodes v as an inline TOML value.
+func marshalTOMLValue(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(map[string]any{"v": v}); err != nil {
+		return nil, err
+	}
+
+	out := bytes.TrimSpace(buf.Bytes())
+	if !bytes.HasPrefix(out, []byte("v = ")) {
+		return nil, fmt.Errorf("unsupported TOML value type: %T", v)
+	}
+	return out[len("v = "):], nil
+}
@@ -0,0 +1,83 @@
+//go:build toml
+
+package gonull
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+)
+
+type tomlConfig struct {
+	Name     Nullable[string]    `toml:"name"`
+	Port     Nullable[int]       `toml:"port"`
+	Ratio    Nullable[float64]   `toml:"ratio"`
+	Tags     Nullable[[]string]  `toml:"tags"`
+	Deadline Nullable[time.Time] `toml:"deadline"`
+	Comment  Nullable[string]    `toml:"comment,omitempty"`
+}
+
+func TestNullableTOMLRoundTrip(t *testing.T) {
+	deadline := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	cfg := tomlConfig{
+		Name:     NewNullable("api \"edge\""),
+		Port:     NewNullable(8080),
+		Ratio:    NewNullable(0.25),
+		Tags:     NewNullable([]string{"a", "b"}),
+		Deadline: NewNullable(deadline),
+	}
+
+	data, err := toml.Marshal(cfg)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "comment")
+
+	var decoded tomlConfig
+	_, err = toml.Decode(string(data), &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, cfg.Name, decoded.Name)
+	assert.Equal(t, cfg.Port, decoded.Port)
+	assert.Equal(t, cfg.Ratio, decoded.Ratio)
+	assert.Equal(t, cfg.Tags, decoded.Tags)
+	assert.True(t, deadline.Equal(decoded.Deadline.Val))
+	assert.False(t, decoded.Comment.Present)
+	assert.False(t, decoded.Comment.Valid)
+}
+
+func TestSetTOMLNullMode(t *testing.T) {
+	type config struct {
+		Name Nullable[string] `toml:"name"`
+		Port Nullable[int]    `toml:"port,omitempty"`
+	}
+	cfg := config{Name: NewNullable("api"), Port: Nullable[int]{Present: true}}
+
+	t.Run("error", func(t *testing.T) {
+		_, err := toml.Marshal(cfg)
+		assert.ErrorIs(t, err, ErrTOMLNull)
+
+		var buf bytes.Buffer
+		assert.ErrorIs(t, EncodeTOML(&buf, cfg), ErrTOMLNull)
+	})
+
+	t.Run("absent", func(t *testing.T) {
+		SetTOMLNullMode(TOMLNullAbsent)
+		defer SetTOMLNullMode(TOMLNullError)
+
+		var buf bytes.Buffer
+		assert.NoError(t, EncodeTOML(&buf, &cfg))
+		assert.Equal(t, "name = \"api\"\n", buf.String())
+		assert.True(t, cfg.Port.Present)
+
+		buf.Reset()
+		doc := map[string]any{"port": cfg.Port, "nested": map[string]Nullable[int]{"a": NewNullable(1), "b": cfg.Port}}
+		assert.NoError(t, EncodeTOML(&buf, doc))
+		assert.Equal(t, "[nested]\n  a = 1\n", buf.String())
+
+		var decoded config
+		_, err := toml.Decode("name = \"api\"\n", &decoded)
+		assert.NoError(t, err)
+		assert.False(t, decoded.Port.Present)
+	})
+}
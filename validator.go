@@ -0,0 +1,38 @@
+//go:build validator
+
+package gonull
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RegisterValidation teaches the given go-playground validator to unwrap Nullable fields.
+// A valid Nullable is validated as its underlying Val, while an invalid one is presented to the validator as nil,
+// so constraints are skipped for fields tagged with omitempty and "required" reports the field as missing.
+//
+// Custom type functions are registered per concrete type, so Nullables of the common scalar types are registered
+// by default and any other instantiation, e.g. Nullable[MyType]{}, must be passed in types.
+//
+// This file is only built with the "validator" build tag.
+func RegisterValidation(v *validator.Validate, types ...any) {
+	defaults := []any{
+		Nullable[string]{}, Nullable[bool]{}, Nullable[[]byte]{}, Nullable[time.Time]{},
+		Nullable[int]{}, Nullable[int8]{}, Nullable[int16]{}, Nullable[int32]{}, Nullable[int64]{},
+		Nullable[uint]{}, Nullable[uint8]{}, Nullable[uint16]{}, Nullable[uint32]{}, Nullable[uint64]{},
+		Nullable[float32]{}, Nullable[float64]{},
+	}
+
+	v.RegisterCustomTypeFunc(nullableValidationValue, append(defaults, types...)...)
+}
+
+// nullableValidationValue returns the value the validator should check for a Nullable field,
+// which is Val when the Nullable is valid and nil otherwise.
+func nullableValidationValue(field reflect.Value) any {
+	if !field.FieldByName("Valid").Bool() {
+		return nil
+	}
+	return field.FieldByName("Val").Interface()
+}
@@ -0,0 +1,64 @@
+//go:build validator
+
+package gonull
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+type validatedUser struct {
+	Name  Nullable[string] `validate:"omitempty,min=3"`
+	Age   Nullable[int]    `validate:"omitempty,gte=18"`
+	Email Nullable[string] `validate:"required,email"`
+}
+
+type customValidated string
+
+func TestRegisterValidation(t *testing.T) {
+	v := validator.New()
+	RegisterValidation(v)
+
+	t.Run("invalid nullable skips constraints", func(t *testing.T) {
+		user := validatedUser{
+			Name:  Nullable[string]{Present: true},
+			Email: NewNullable("alice@example.com"),
+		}
+		assert.NoError(t, v.Struct(user))
+	})
+
+	t.Run("valid nullable enforces constraints", func(t *testing.T) {
+		user := validatedUser{
+			Name:  NewNullable("Al"),
+			Age:   NewNullable(16),
+			Email: NewNullable("alice@example.com"),
+		}
+		err := v.Struct(user)
+		assert.Error(t, err)
+
+		var errs validator.ValidationErrors
+		assert.ErrorAs(t, err, &errs)
+		assert.Len(t, errs, 2)
+		assert.Equal(t, "min", errs[0].Tag())
+		assert.Equal(t, "gte", errs[1].Tag())
+	})
+
+	t.Run("required reports invalid nullable", func(t *testing.T) {
+		err := v.Struct(validatedUser{})
+		assert.Error(t, err)
+	})
+
+	t.Run("custom types", func(t *testing.T) {
+		type custom struct {
+			Field Nullable[customValidated] `validate:"omitempty,oneof=a b"`
+		}
+
+		v := validator.New()
+		RegisterValidation(v, Nullable[customValidated]{})
+		assert.NoError(t, v.Struct(custom{Field: NewNullable[customValidated]("a")}))
+		assert.Error(t, v.Struct(custom{Field: NewNullable[customValidated]("c")}))
+		assert.NoError(t, v.Struct(custom{}))
+	})
+}
@@ -0,0 +1,133 @@
+package gonull
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// xsiNamespace is the XML Schema instance namespace, which defines the nil attribute.
+const xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// MarshalXML implements the xml.Marshaler interface for Nullable. Valid values are encoded as the element holding
+// the value, the same way the encoder writes T. Invalid values are written according to SetXMLNullMode,
+// either as an element with xsi:nil="true" or as an empty element. Absent values are left out of the document,
+// so that decoding it leaves them absent again.
+func (n Nullable[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if !n.Present && !n.Valid {
+		return nil
+	}
+
+	if n.Valid {
+		return e.EncodeElement(n.Val, start)
+	}
+
+	if loadSettings().xmlNullMode == XMLNullNil {
+		start.Attr = append(start.Attr,
+			xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: xsiNamespace},
+			xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"},
+		)
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface for Nullable. An element in the document marks
+// the Nullable as present, while missing elements never reach UnmarshalXML, so the Nullable stays absent.
+// An element with xsi:nil="true" is decoded as a null value, and so is an element without any content, not even
+// whitespace, when SetXMLNullMode is XMLNullEmpty. Other elements are decoded into T.
+func (n *Nullable[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	n.Present = true
+	n.Val = zeroValue[T]()
+	n.Valid = false
+
+	if isXMLNil(start) {
+		return d.Skip()
+	}
+
+	// The content is buffered to find out whether the element is empty before it is decoded.
+	tokens := []xml.Token{start.Copy()}
+	empty := true
+	for depth := 0; ; {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+			empty = false
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			if len(t) > 0 {
+				empty = false
+			}
+		}
+		tokens = append(tokens, xml.CopyToken(token))
+		if depth < 0 {
+			break
+		}
+	}
+
+	if empty && loadSettings().xmlNullMode == XMLNullEmpty {
+		return nil
+	}
+
+	var value T
+	replay := xml.NewTokenDecoder(&tokenReplay{tokens: tokens})
+	if err := replay.Decode(&value); err != nil {
+		return err
+	}
+
+	n.Val = normalize(value)
+	n.Valid = true
+	return nil
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface for Nullable, which is used for fields tagged
+// `xml:",attr"`. Absent values are left out of the element, so that decoding it leaves them absent again.
+// Null values are written as empty attributes and valid values as the output of MarshalText.
+func (n Nullable[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !n.Present && !n.Valid {
+		return xml.Attr{}, nil
+	}
+
+	text, err := n.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface for Nullable. An attribute on the element marks
+// the Nullable as present, while missing attributes never reach UnmarshalXMLAttr, so the Nullable stays absent.
+// The value is decoded like UnmarshalText, so an empty attribute is decoded as a null value.
+func (n *Nullable[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.UnmarshalText([]byte(attr.Value))
+}
+
+// isXMLNil reports whether the element carries an xsi:nil attribute set to true.
+func isXMLNil(start xml.StartElement) bool {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "nil" && (attr.Name.Space == xsiNamespace || attr.Name.Space == "xsi") {
+			return attr.Value == "true" || attr.Value == "1"
+		}
+	}
+	return false
+}
+
+// tokenReplay is an xml.TokenReader returning buffered tokens, followed by io.EOF.
+type tokenReplay struct {
+	tokens []xml.Token
+}
+
+func (r *tokenReplay) Token() (xml.Token, error) {
+	if len(r.tokens) == 0 {
+		return nil, io.EOF
+	}
+	token := r.tokens[0]
+	r.tokens = r.tokens[1:]
+	return token, nil
+}
@@ -0,0 +1,128 @@
+package gonull
+
+import (
+	"encoding/xml"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type xmlPerson struct {
+	XMLName  xml.Name          `xml:"person"`
+	Name     Nullable[string]  `xml:"name"`
+	Age      Nullable[int]     `xml:"age"`
+	Nickname Nullable[string]  `xml:"nickname"`
+	Address  Nullable[xmlAddr] `xml:"address"`
+}
+
+type xmlAddr struct {
+	City string `xml:"city"`
+}
+
+func TestNullableMarshalXML(t *testing.T) {
+	defer SetXMLNullMode(XMLNullNil)
+
+	person := xmlPerson{
+		Name:    NewNullable("Ana"),
+		Age:     Nullable[int]{Present: true},
+		Address: NewNullable(xmlAddr{City: "Tbilisi"}),
+	}
+
+	data, err := xml.Marshal(person)
+	assert.NoError(t, err)
+	assert.Equal(t, `<person><name>Ana</name>`+
+		`<age xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></age>`+
+		`<address><city>Tbilisi</city></address></person>`, string(data))
+
+	SetXMLNullMode(XMLNullEmpty)
+	data, err = xml.Marshal(person)
+	assert.NoError(t, err)
+	assert.Equal(t, `<person><name>Ana</name><age></age><address><city>Tbilisi</city></address></person>`, string(data))
+}
+
+func TestNullableUnmarshalXML(t *testing.T) {
+	defer SetXMLNullMode(XMLNullNil)
+
+	input := `<person xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">` +
+		`<name>Ana</name><age xsi:nil="true"/><nickname></nickname>` +
+		`<address><city>Tbilisi</city></address></person>`
+
+	var person xmlPerson
+	assert.NoError(t, xml.Unmarshal([]byte(input), &person))
+	assert.Equal(t, NewNullable("Ana"), person.Name)
+	assert.Equal(t, Nullable[int]{Present: true}, person.Age)
+	assert.Equal(t, NewNullable(""), person.Nickname)
+	assert.Equal(t, NewNullable(xmlAddr{City: "Tbilisi"}), person.Address)
+
+	SetXMLNullMode(XMLNullEmpty)
+	person = xmlPerson{}
+	assert.NoError(t, xml.Unmarshal([]byte(`<person><nickname></nickname><age>30</age></person>`), &person))
+	assert.Equal(t, Nullable[string]{Present: true}, person.Nickname)
+	assert.Equal(t, NewNullable(30), person.Age)
+	assert.Equal(t, Nullable[string]{}, person.Name)
+	assert.False(t, person.Address.Present)
+
+	person = xmlPerson{}
+	assert.NoError(t, xml.Unmarshal([]byte(`<person><nickname> </nickname><name/></person>`), &person))
+	assert.Equal(t, NewNullable(" "), person.Nickname)
+	assert.Equal(t, Nullable[string]{Present: true}, person.Name)
+
+	assert.Error(t, xml.Unmarshal([]byte(`<person><age>old</age></person>`), &person))
+}
+
+type xmlItem struct {
+	XMLName xml.Name         `xml:"item"`
+	ID      Nullable[int]    `xml:"id,attr"`
+	Label   Nullable[string] `xml:"label,attr"`
+}
+
+func TestNullableXMLAttr(t *testing.T) {
+	data, err := xml.Marshal(xmlItem{})
+	assert.NoError(t, err)
+	assert.Equal(t, `<item></item>`, string(data))
+
+	data, err = xml.Marshal(xmlItem{ID: NewNullable(7), Label: Nullable[string]{Present: true}})
+	assert.NoError(t, err)
+	assert.Equal(t, `<item id="7" label=""></item>`, string(data))
+
+	var item xmlItem
+	assert.NoError(t, xml.Unmarshal(data, &item))
+	assert.Equal(t, NewNullable(7), item.ID)
+	assert.Equal(t, Nullable[string]{Present: true}, item.Label)
+
+	item = xmlItem{}
+	assert.NoError(t, xml.Unmarshal([]byte(`<item></item>`), &item))
+	assert.Equal(t, Nullable[int]{}, item.ID)
+	assert.Equal(t, Nullable[string]{}, item.Label)
+
+	assert.Error(t, xml.Unmarshal([]byte(`<item id="x"></item>`), &item))
+}
+
+func TestTokenReplay(t *testing.T) {
+	replay := &tokenReplay{tokens: []xml.Token{xml.CharData("x")}}
+	token, err := replay.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, xml.CharData("x"), token)
+
+	token, err = replay.Token()
+	assert.Nil(t, token)
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestNullableXMLRoundTrip(t *testing.T) {
+	person := xmlPerson{
+		Name:     NewNullable("Ana"),
+		Nickname: Nullable[string]{Present: true},
+	}
+
+	data, err := xml.Marshal(person)
+	assert.NoError(t, err)
+
+	var decoded xmlPerson
+	assert.NoError(t, xml.Unmarshal(data, &decoded))
+	assert.Equal(t, person.Name, decoded.Name)
+	assert.Equal(t, person.Nickname, decoded.Nickname)
+	assert.Equal(t, Nullable[int]{}, decoded.Age)
+	assert.Equal(t, Nullable[xmlAddr]{}, decoded.Address)
+}
@@ -0,0 +1,44 @@
+//go:build yaml
+
+package gonull
+
+import "gopkg.in/yaml.v3"
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v3 for Nullable.
+// Valid values are encoded as the underlying value and invalid values as null. Absent values can be left out
+// of the document by tagging the field with `yaml:",omitempty"`, since they are zero structs.
+//
+// This file is only built with the "yaml" build tag.
+func (n Nullable[T]) MarshalYAML() (any, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Val, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v3 for Nullable.
+// A key present in the document marks the Nullable as present, and a !!null node leaves it invalid.
+//
+// Note that yaml.v3 doesn't call unmarshalers for null nodes of struct fields, so a field explicitly set to null
+// is decoded like a missing one, as absent. UnmarshalYAML still handles !!null nodes passed to it directly,
+// e.g. by the custom unmarshaler of a parent type.
+func (n *Nullable[T]) UnmarshalYAML(value *yaml.Node) error {
+	n.Present = true
+
+	if value.ShortTag() == "!!null" {
+		n.Val = zeroValue[T]()
+		n.Valid = false
+		return nil
+	}
+
+	var v T
+	if err := value.Decode(&v); err != nil {
+		n.Val = zeroValue[T]()
+		n.Valid = false
+		return err
+	}
+
+	n.Val = normalize(v)
+	n.Valid = true
+	return nil
+}
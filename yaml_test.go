@@ -0,0 +1,68 @@
+//go:build yaml
+
+package gonull
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type yamlConfig struct {
+	Name    Nullable[string]   `yaml:"name"`
+	Port    Nullable[int]      `yaml:"port"`
+	Tags    Nullable[[]string] `yaml:"tags"`
+	Comment Nullable[string]   `yaml:"comment,omitempty"`
+}
+
+func TestNullableMarshalYAML(t *testing.T) {
+	cfg := yamlConfig{
+		Name: NewNullable("api"),
+		Port: Nullable[int]{Present: true},
+		Tags: NewNullable([]string{"a", "b"}),
+	}
+
+	data, err := yaml.Marshal(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "name: api\nport: null\ntags:\n    - a\n    - b\n", string(data))
+}
+
+func TestNullableUnmarshalYAML(t *testing.T) {
+	var cfg yamlConfig
+	err := yaml.Unmarshal([]byte("name: api\nport: 8080\ntags: [a, b]\n"), &cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, NewNullable("api"), cfg.Name)
+	assert.Equal(t, NewNullable(8080), cfg.Port)
+	assert.Equal(t, NewNullable([]string{"a", "b"}), cfg.Tags)
+	assert.Equal(t, Nullable[string]{}, cfg.Comment)
+
+	err = yaml.Unmarshal([]byte("port: eighty\n"), &cfg)
+	assert.Error(t, err)
+	assert.True(t, cfg.Port.Present)
+	assert.False(t, cfg.Port.Valid)
+
+	var node yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte("~"), &node))
+	n := NewNullable(1)
+	assert.NoError(t, n.UnmarshalYAML(node.Content[0]))
+	assert.Equal(t, Nullable[int]{Present: true}, n)
+
+	// yaml.v3 skips unmarshalers for null struct fields, so they are decoded as absent.
+	cfg = yamlConfig{}
+	assert.NoError(t, yaml.Unmarshal([]byte("name: null\n"), &cfg))
+	assert.Equal(t, Nullable[string]{}, cfg.Name)
+}
+
+func TestNullableYAMLRoundTrip(t *testing.T) {
+	cfg := yamlConfig{Name: NewNullable("edge"), Port: NewNullable(443)}
+
+	data, err := yaml.Marshal(cfg)
+	assert.NoError(t, err)
+
+	var decoded yamlConfig
+	assert.NoError(t, yaml.Unmarshal(data, &decoded))
+	assert.Equal(t, cfg.Name, decoded.Name)
+	assert.Equal(t, cfg.Port, decoded.Port)
+	assert.False(t, decoded.Comment.Present)
+}